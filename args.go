@@ -4,42 +4,73 @@ import (
 	"fmt"
 	"github.com/muesli/termenv"
 	"os"
+	"strings"
 
+	"github.com/operatios/lsg/ls"
 	flag "github.com/spf13/pflag"
 )
 
 const (
 	helpAll       = "do not ignore hidden files"
+	helpDirectory = "list directories themselves, not their contents"
 	helpLongList  = "use a long listing format"
 	helpBytes     = "with -l: print size in bytes"
+	helpSI        = "with -l: print sizes in powers of 1000, not 1024"
+	helpBlockSize = "with -l: print sizes in units of K, M, G, KB, MB, ...; a leading number like 1K drops the suffix"
+	helpPrecision = "with -l: digits after the decimal point of sizes (-1 = one below 9 units, none above)"
+	helpGroup     = "with -b or --block-size: separate thousands with commas"
+	helpBlocks    = "with -l: print the space files take up on disk instead of their size"
 	helpExtend    = "with -l: print filemode and owner/group info"
+	helpOctal     = "with -l: print permissions in octal"
+	helpAccess    = "with -l: print what the current user may do (rwx)"
+	helpAttrs     = "with -l: print inode flags like lsattr (Linux only)"
+	helpSizeBar   = "with -l or -t: width of a bar showing each file's share of the total size (0 = none)"
+	helpSizeColor = "color sizes by fixed thresholds, or by percentile or log scale within the listing"
+	helpHeader    = "with -l: print a header row naming the columns"
+	helpEntries   = "with -l: print the number of entries of directories instead of their size"
+	helpHidden    = "with --entries or --sort=entries: count hidden entries too"
 	helpTree      = "use a tree format"
-	helpSort      = "sort by size (s), time (t), extension (x), category (c)"
+	helpCompact   = "with -t: merge chains of directories holding only one directory into a single a/b/c entry"
+	helpRecursive = "list subdirectories recursively"
+	helpLevel     = "with -t or -R: descend at most this many levels (0 = no limit)"
+	helpJSON      = "print files as JSON"
+	helpSummary   = "print counts, sizes by category and free disk space after a listing"
+	helpIGlob     = "match glob patterns case-insensitively"
+	helpFlat      = "list all glob matches together by their path"
+	helpSort      = "sort by name (n), size (s), time (t), extension (x), category (c), version (v), entries (e); comma separated, -key reverses a key"
+	helpCollate   = "compare names by bytes, the locale of the environment or a language tag"
+	helpGroupDirs = "group directories first, last or none"
 	helpReverse   = "reverse file order"
 	helpColumns   = "set maximum amount of columns"
 	helpColSep    = "set column separator length"
+	helpSeparator = "draw this between columns instead of --col-sep spaces"
+	helpAcross    = "fill grid rows first instead of columns"
+	helpClassify  = "append indicator (one of /*@|=>) to entries"
 	helpNoTargets = "disable link targets"
-	helpNoColors  = "disable colors"
-	helpNoIcons   = "disable icons"
+	helpTruncate  = "shorten names wider than the output in the middle or at the end, or none"
+	helpColor     = "use colors: auto, always or never"
+	helpIcons     = "use icons: auto, always or never"
+	helpIconSet   = "icons to use: nerd-v3, nerd-v2 (Nerd Fonts before v3), emoji or ascii"
+	helpWidth     = "width of the output (default: $COLUMNS, else the width of the terminal, else one column)"
+	helpNoColors  = "disable colors, same as --color=never"
+	helpNoIcons   = "disable icons, same as --icons=never"
+	helpDepth     = "colors the terminal can show: auto, truecolor, 256, 16 or mono"
 	helpShow      = "show this message and exit"
 )
 
 type Args struct {
-	paths      []string
-	all        bool
-	longList   bool
-	bytes      bool
-	listExtend bool
-	tree       bool
-	sort       string
-	reverse    bool
-	columns    int
-	colSep     int
-	noTargets  bool
-	noColors   bool
-	noIcons    bool
-	dark       bool
-	light      bool
+	ls.Options
+	paths     []string
+	longList  bool
+	tree      bool
+	recursive bool
+	json      bool
+	flat      bool
+	dark      bool
+	light     bool
+	depth     string
+	color     string
+	icons     string
 }
 
 func getArgs() Args {
@@ -47,18 +78,52 @@ func getArgs() Args {
 
 	flag.CommandLine.SortFlags = false
 
-	flag.BoolVarP(&args.all, "all", "a", false, helpAll)
+	flag.BoolVarP(&args.All, "all", "a", false, helpAll)
+	flag.BoolVarP(&args.Directory, "directory", "d", false, helpDirectory)
 	flag.BoolVarP(&args.longList, "long-listing", "l", false, helpLongList)
-	flag.BoolVarP(&args.bytes, "bytes", "b", false, helpBytes)
-	flag.BoolVarP(&args.listExtend, "extend", "x", false, helpExtend)
+	flag.BoolVarP(&args.Bytes, "bytes", "b", false, helpBytes)
+	flag.BoolVar(&args.SI, "si", false, helpSI)
+	flag.StringVar(&args.BlockSize, "block-size", "", helpBlockSize)
+	args.Precision = flag.Int("precision", -1, helpPrecision)
+	flag.BoolVar(&args.GroupDigits, "group-digits", false, helpGroup)
+	flag.BoolVar(&args.Blocks, "blocks", false, helpBlocks)
+	flag.BoolVarP(&args.Extend, "extend", "x", false, helpExtend)
+	flag.BoolVar(&args.Octal, "octal", false, helpOctal)
+	flag.BoolVar(&args.Access, "access", false, helpAccess)
+	flag.BoolVar(&args.Attrs, "attrs", false, helpAttrs)
+	flag.BoolVar(&args.Header, "header", false, helpHeader)
+	flag.BoolVar(&args.Entries, "entries", false, helpEntries)
+	flag.BoolVar(&args.CountHidden, "count-hidden", false, helpHidden)
+	flag.IntVar(&args.SizeBar, "size-bar", 0, helpSizeBar)
+	flag.StringVar(&args.SizeColors, "size-colors", "fixed", helpSizeColor)
 	flag.BoolVarP(&args.tree, "tree", "t", false, helpTree)
-	flag.StringVarP(&args.sort, "sort", "s", "", helpSort)
-	flag.BoolVarP(&args.reverse, "reverse", "r", false, helpReverse)
-	flag.IntVarP(&args.columns, "columns", "c", 0, helpColumns)
-	flag.IntVar(&args.colSep, "col-sep", 2, helpColSep)
-	flag.BoolVar(&args.noTargets, "no-targets", false, helpNoTargets)
-	flag.BoolVar(&args.noColors, "no-colors", false, helpNoColors)
-	flag.BoolVar(&args.noIcons, "no-icons", false, helpNoIcons)
+	flag.BoolVar(&args.Compact, "compact", false, helpCompact)
+	flag.BoolVarP(&args.recursive, "recursive", "R", false, helpRecursive)
+	flag.IntVarP(&args.Level, "level", "L", 0, helpLevel)
+	flag.BoolVar(&args.json, "json", false, helpJSON)
+	flag.BoolVar(&args.Summary, "summary", false, helpSummary)
+	flag.BoolVar(&args.GlobIgnoreCase, "iglob", false, helpIGlob)
+	flag.BoolVar(&args.flat, "flat", false, helpFlat)
+	flag.StringVarP(&args.Sort, "sort", "s", "", helpSort)
+	flag.BoolVarP(&args.Reverse, "reverse", "r", false, helpReverse)
+	flag.StringVar(&args.GroupDirs, "group-dirs", "none", helpGroupDirs)
+	flag.StringVar(&args.Collate, "collate", "bytes", helpCollate)
+	flag.IntVarP(&args.Columns, "columns", "c", 0, helpColumns)
+	args.ColSep = flag.Int("col-sep", 2, helpColSep)
+	flag.StringVar(&args.Separator, "separator", "", helpSeparator)
+	flag.BoolVar(&args.Across, "across", false, helpAcross)
+	flag.BoolVarP(&args.Classify, "classify", "F", false, helpClassify)
+	flag.BoolVar(&args.NoTargets, "no-targets", false, helpNoTargets)
+	flag.StringVar(&args.Truncate, "truncate", "none", helpTruncate)
+	flag.StringVar(&args.color, "color", "auto", helpColor)
+	flag.StringVar(&args.icons, "icons", "auto", helpIcons)
+	flag.Lookup("color").NoOptDefVal = "always"
+	flag.Lookup("icons").NoOptDefVal = "always"
+	flag.StringVar(&args.IconSet, "icon-set", "nerd-v3", helpIconSet)
+	flag.IntVar(&args.Width, "width", 0, helpWidth)
+	flag.BoolVar(&args.NoColors, "no-colors", false, helpNoColors)
+	flag.BoolVar(&args.NoIcons, "no-icons", false, helpNoIcons)
+	flag.StringVar(&args.depth, "color-depth", "auto", helpDepth)
 	flag.BoolVar(&args.dark, "dark", false, "Enable dark theme color output")
 	flag.BoolVar(&args.light, "light", false, "Enable light theme color output")
 
@@ -71,10 +136,43 @@ func getArgs() Args {
 		os.Exit(0)
 	}
 
-	if args.colSep < 0 {
-		_, _ = fmt.Fprintln(os.Stderr, "column separator length should be >=0")
+	// Operands after "--" are never exclusions, so "!name" can be listed.
+	dash := flag.CommandLine.ArgsLenAtDash()
+	for i, path := range flag.Args() {
+		if strings.HasPrefix(path, "!") && (dash < 0 || i < dash) {
+			args.Exclude = append(args.Exclude, path[1:])
+		} else {
+			args.paths = append(args.paths, path)
+		}
+	}
+
+	if len(args.paths) == 0 {
+		args.paths = append(args.paths, ".")
+	}
+
+	if err := args.Validate(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+	for name, when := range map[string]string{"color": args.color, "icons": args.icons} {
+		if when != "auto" && when != "always" && when != "never" {
+			_, _ = fmt.Fprintf(os.Stderr, "invalid --%s: %s\n", name, when)
+			os.Exit(1)
+		}
+	}
+	if args.Width < 0 {
+		_, _ = fmt.Fprintln(os.Stderr, "width should be >=0")
+		os.Exit(1)
+	}
+
+	depth := detectColorDepth()
+	if args.depth != "auto" {
+		var err error
+		if depth, err = ls.ParseColorDepth(args.depth); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
 
 	if !args.dark && !args.light {
 		if termenv.HasDarkBackground() {
@@ -85,11 +183,11 @@ func getArgs() Args {
 	}
 
 	if args.dark {
-		theme = Dark
+		args.Theme = ls.Dark
 	} else {
-		theme = Light
+		args.Theme = ls.Light
 	}
+	args.Theme = args.Theme.WithDepth(depth)
 
-	args.paths = flag.Args()
 	return args
 }
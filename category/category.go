@@ -11,8 +11,43 @@ const (
 	Image
 	Audio
 	Video
+	Fifo
+	Socket
+	BlockDevice
+	CharDevice
+	Door
+	Setuid
+	Setgid
+	Sticky
+	OtherWritable
+	StickyOtherWritable
 )
 
+// Names are short lowercase names of the categories.
+var Names = map[int]string{
+	Dir:         "dir",
+	File:        "file",
+	Symlink:     "symlink",
+	Broken:      "broken",
+	Archive:     "archive",
+	Executable:  "executable",
+	Code:        "code",
+	Image:       "image",
+	Audio:       "audio",
+	Video:       "video",
+	Fifo:        "fifo",
+	Socket:      "socket",
+	BlockDevice: "block-device",
+	CharDevice:  "char-device",
+	Door:        "door",
+	Setuid:      "setuid",
+	Setgid:      "setgid",
+	Sticky:      "sticky",
+
+	OtherWritable:       "other-writable",
+	StickyOtherWritable: "sticky-other-writable",
+}
+
 var Extensions = map[string]int{
 	".7z":   Archive,
 	".a":    Archive,
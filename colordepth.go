@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/operatios/lsg/ls"
+)
+
+// detectColorDepth returns the color depth the terminal announces through
+// COLORTERM, TERM and the terminfo entry of TERM. Unknown terminals get
+// true colors.
+func detectColorDepth() ls.ColorDepth {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ls.TrueColor
+	}
+
+	term := os.Getenv("TERM")
+	switch {
+	case term == "":
+		return ls.TrueColor
+	case strings.HasSuffix(term, "-direct") || strings.Contains(term, "truecolor"):
+		return ls.TrueColor
+	}
+
+	if colors, ok := terminfoColors(term); ok {
+		switch {
+		case colors >= 1<<24:
+			return ls.TrueColor
+		case colors >= 256:
+			return ls.Colors256
+		case colors >= 8:
+			return ls.Colors16
+		}
+		return ls.Monochrome
+	}
+
+	if strings.Contains(term, "256color") {
+		return ls.Colors256
+	}
+	for _, prefix := range []string{"linux", "vt", "xterm", "screen", "tmux", "rxvt", "ansi", "cygwin", "putty"} {
+		if strings.HasPrefix(term, prefix) {
+			return ls.Colors16
+		}
+	}
+	return ls.TrueColor
+}
+
+// terminfoColors returns the "colors" capability of the compiled terminfo
+// entry for term, or false if there is none.
+func terminfoColors(term string) (int, bool) {
+	data, ok := readTerminfo(term)
+	if !ok || len(data) < 12 {
+		return 0, false
+	}
+
+	header := make([]int, 6)
+	for i := range header {
+		header[i] = int(binary.LittleEndian.Uint16(data[2*i:]))
+	}
+
+	numberSize := 0
+	switch header[0] {
+	case 0o432:
+		numberSize = 2
+	case 0o1036:
+		numberSize = 4
+	default:
+		return 0, false
+	}
+
+	const colorsIndex = 13
+	if header[3] <= colorsIndex {
+		return -1, true
+	}
+
+	offset := 12 + header[1] + header[2]
+	if offset%2 != 0 {
+		offset++
+	}
+	offset += colorsIndex * numberSize
+	if offset+numberSize > len(data) {
+		return 0, false
+	}
+
+	if numberSize == 2 {
+		return int(int16(binary.LittleEndian.Uint16(data[offset:]))), true
+	}
+	return int(int32(binary.LittleEndian.Uint32(data[offset:]))), true
+}
+
+// readTerminfo reads the compiled terminfo entry for term from the
+// directories ncurses searches.
+func readTerminfo(term string) ([]byte, bool) {
+	if strings.ContainsAny(term, `/\`) {
+		return nil, false
+	}
+
+	var dirs []string
+	if dir := os.Getenv("TERMINFO"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+
+	defaults := []string{"/etc/terminfo", "/lib/terminfo", "/usr/share/terminfo", "/usr/lib/terminfo", "/usr/share/misc/terminfo"}
+	if env := os.Getenv("TERMINFO_DIRS"); env != "" {
+		for _, dir := range strings.Split(env, ":") {
+			if dir == "" {
+				dirs = append(dirs, defaults...)
+			} else {
+				dirs = append(dirs, dir)
+			}
+		}
+	} else {
+		dirs = append(dirs, defaults...)
+	}
+
+	for _, dir := range dirs {
+		// Entries are in a directory named after their first letter, or
+		// on macOS its hex code.
+		for _, sub := range []string{term[:1], fmt.Sprintf("%x", term[0])} {
+			if data, err := os.ReadFile(filepath.Join(dir, sub, term)); err == nil {
+				return data, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -1,13 +1,14 @@
 module github.com/operatios/lsg
 
-go 1.15
+go 1.16
 
 require (
 	github.com/bmatcuk/doublestar/v2 v2.0.1
 	github.com/gookit/color v1.3.3
-	github.com/mattn/go-runewidth v0.0.13 // indirect
-	github.com/muesli/termenv v0.8.1 // indirect
+	github.com/mattn/go-runewidth v0.0.13
+	github.com/muesli/termenv v0.8.1
 	github.com/spf13/pflag v1.0.5
 	golang.org/x/crypto v0.0.0-20200820211705-5c72a883971a
 	golang.org/x/sys v0.0.0-20210521203332-0cec03c779c1
+	golang.org/x/text v0.3.6
 )
@@ -0,0 +1,33 @@
+package icons
+
+import "github.com/operatios/lsg/category"
+
+// ASCII is an icon set of single letters and symbols for any terminal,
+// similar to the file types of ls -l.
+var ASCII = &Set{
+	Categories: map[int]string{
+		category.Dir:         "d",
+		category.File:        "-",
+		category.Symlink:     "l",
+		category.Broken:      "!",
+		category.Archive:     "z",
+		category.Executable:  "*",
+		category.Code:        "#",
+		category.Image:       "i",
+		category.Audio:       "a",
+		category.Video:       "v",
+		category.Fifo:        "p",
+		category.Socket:      "s",
+		category.BlockDevice: "b",
+		category.CharDevice:  "c",
+		category.Door:        "D",
+		category.Setuid:      "u",
+		category.Setgid:      "g",
+		category.Sticky:      "t",
+
+		category.OtherWritable:       "w",
+		category.StickyOtherWritable: "T",
+	},
+	LinkDir:   "L",
+	LinkArrow: "->",
+}
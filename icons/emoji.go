@@ -0,0 +1,71 @@
+package icons
+
+import "github.com/operatios/lsg/category"
+
+// Emoji is an icon set for terminals without Nerd Fonts but with color
+// emoji. Only emoji shown as such without a variation selector are used.
+var Emoji = &Set{
+	Categories: map[int]string{
+		category.Dir:         "📁",
+		category.File:        "📄",
+		category.Symlink:     "🔗",
+		category.Broken:      "💔",
+		category.Archive:     "📦",
+		category.Executable:  "🚀",
+		category.Code:        "📝",
+		category.Image:       "🎨",
+		category.Audio:       "🎵",
+		category.Video:       "🎬",
+		category.Fifo:        "🚰",
+		category.Socket:      "🔌",
+		category.BlockDevice: "💽",
+		category.CharDevice:  "📟",
+		category.Door:        "🚪",
+		category.Setuid:      "🔑",
+		category.Setgid:      "👥",
+		category.Sticky:      "📌",
+
+		category.OtherWritable:       "🔓",
+		category.StickyOtherWritable: "📎",
+	},
+	Extensions: map[string]string{
+		".apk":    "🤖",
+		".bat":    "💻",
+		".class":  "☕",
+		".css":    "🎨",
+		".csv":    "📊",
+		".db":     "💾",
+		".dmg":    "🍎",
+		".doc":    "📘",
+		".docx":   "📘",
+		".epub":   "📚",
+		".exe":    "💻",
+		".go":     "🐹",
+		".html":   "🌐",
+		".htm":    "🌐",
+		".jar":    "☕",
+		".java":   "☕",
+		".js":     "📜",
+		".json":   "🔧",
+		".log":    "📜",
+		".md":     "📝",
+		".msi":    "💻",
+		".pdf":    "📕",
+		".py":     "🐍",
+		".rs":     "🦀",
+		".sh":     "🐚",
+		".sqlite": "💾",
+		".txt":    "📄",
+		".xls":    "📊",
+		".xlsx":   "📊",
+	},
+	Names: map[string]string{
+		".git":       "🌱",
+		"dockerfile": "🐳",
+		"license":    "📜",
+		"makefile":   "🔨",
+		"readme.md":  "📖",
+	},
+	LinkDir:   "🔗",
+	LinkArrow: "→",
+}
@@ -1,226 +1,75 @@
 package icons
 
-const (
-	File = ""
-	Dir  = ""
+import (
+	"strings"
 
-	LinkFile  = ""
-	LinkDir   = ""
-	LinkArrow = "↪"
-
-	Archive = ""
-	Audio   = "ﱘ"
-	Image   = ""
-	Video   = ""
-
-	CLang   = ""
-	Clojure = ""
-	CPP     = ""
-	CSharp  = ""
-	Python  = ""
-	Shell   = ""
-	Subl    = ""
-	Win     = ""
-	Word    = ""
-	Pdf     = ""
-	Excel   = ""
-	Html    = ""
-	Log     = ""
-	Jar     = ""
-	Xml     = ""
-	Apple   = ""
-	Config  = ""
-	JS      = ""
+	"github.com/operatios/lsg/category"
 )
 
-// To add new icons just add a new key: value pair here
-var Extensions = map[string]string{
-	".apk":              "",
-	".c":                CLang,
-	".h":                CLang,
-	".hpp":              CPP,
-	".hxx":              CPP,
-	".cfg":              Config,
-	".gitignore":        Config,
-	".gitconfig":        Config,
-	".profile":          Config,
-	".zshrc":            Config,
-	".clj":              Clojure,
-	".cljc":             Clojure,
-	".cljs":             Clojure,
-	".coffee":           "",
-	".cc":               CPP,
-	".cp":               CPP,
-	".cpp":              CPP,
-	".cxx":              CPP,
-	".cs":               CSharp,
-	".csproj":           CSharp,
-	".csx":              CSharp,
-	".css":              "",
-	".d":                "",
-	".dart":             "",
-	".db":               "",
-	".ds_store":         Apple,
-	".dmg":              Apple,
-	".go":               "ﳑ",
-	".ipynb":            Python,
-	".md":               "",
-	".py":               Python,
-	".pyc":              Python,
-	".psd":              "",
-	".rs":               "",
-	".vue":              "﵂",
-	".sln":              "",
-	".sql":              "",
-	".sublime_keymap":   Subl,
-	".sublime_package":  Subl,
-	".sublime_settings": Subl,
-	".sublime_theme":    Subl,
-	".txt":              "",
-	".ps1":              Shell,
-	".sh":               Shell,
-	".shell":            Shell,
-	".bat":              Win,
-	".exe":              Win,
-	".msi":              Win,
-
-	".7z":   Archive,
-	".a":    Archive,
-	".ar":   Archive,
-	".bz2":  Archive,
-	".cab":  Archive,
-	".cpio": Archive,
-	".deb":  Archive,
-	".egg":  Archive,
-	".gz":   Archive,
-	".iso":  Archive,
-	".lha":  Archive,
-	".mar":  Archive,
-	".pak":  Archive,
-	".pea":  Archive,
-	".rar":  Archive,
-	".rpm":  Archive,
-	".s7z":  Archive,
-	".shar": Archive,
-	".tar":  Archive,
-	".tbz2": Archive,
-	".tgz":  Archive,
-	".tlz":  Archive,
-	".war":  Archive,
-	".whl":  Archive,
-	".xpi":  Archive,
-	".xz":   Archive,
-	".zip":  Archive,
-	".zipx": Archive,
-
-	".3dm":  Image,
-	".3ds":  Image,
-	".ai":   Image,
-	".bmp":  Image,
-	".dds":  Image,
-	".dwg":  Image,
-	".dxf":  Image,
-	".eps":  Image,
-	".gif":  Image,
-	".gpx":  Image,
-	".jpeg": Image,
-	".jpg":  Image,
-	".kml":  Image,
-	".kmz":  Image,
-	".max":  Image,
-	".png":  Image,
-	".ps":   Image,
-	".svg":  Image,
-	".tga":  Image,
-	".thm":  Image,
-	".tif":  Image,
-	".tiff": Image,
-	".webp": Image,
-	".xcf":  Image,
-	".icns": Image,
-
-	".aac":  Audio,
-	".aiff": Audio,
-	".ape":  Audio,
-	".au":   Audio,
-	".flac": Audio,
-	".gsm":  Audio,
-	".it":   Audio,
-	".m3u":  Audio,
-	".m4a":  Audio,
-	".mid":  Audio,
-	".mp3":  Audio,
-	".mpa":  Audio,
-	".pls":  Audio,
-	".ra":   Audio,
-	".s3m":  Audio,
-	".sid":  Audio,
-	".wav":  Audio,
-	".wma":  Audio,
-	".xm":   Audio,
-
-	".3g2":   Video,
-	".3gp":   Video,
-	".aaf":   Video,
-	".asf":   Video,
-	".avchd": Video,
-	".avi":   Video,
-	".drc":   Video,
-	".flv":   Video,
-	".m2v":   Video,
-	".m4p":   Video,
-	".m4v":   Video,
-	".mkv":   Video,
-	".mng":   Video,
-	".mov":   Video,
-	".mp2":   Video,
-	".mp4":   Video,
-	".mpe":   Video,
-	".mpeg":  Video,
-	".mpg":   Video,
-	".mpv":   Video,
-	".mxf":   Video,
-	".nsv":   Video,
-	".ogg":   Video,
-	".ogm":   Video,
-	".ogv":   Video,
-	".qt":    Video,
-	".rm":    Video,
-	".rmvb":  Video,
-	".roq":   Video,
-	".srt":   Video,
-	".svi":   Video,
-	".vob":   Video,
-	".webm":  Video,
-	".wmv":   Video,
-	".yuv":   Video,
-
-	".jar":   Jar,
-	".java":  "",
-	".class": Jar,
-
-	".js": JS,
-
-	".pdf":  Pdf,
-	".docx": Word,
-	".doc":  Word,
-	".xlsx": Excel,
-	".xls":  Excel,
-	".csv":  Excel,
+// Set is a pack of icons. Files are looked up by their exact name, then
+// their extension, then their category and finally get the icon of
+// category.File.
+type Set struct {
+	Categories map[int]string    // icons by category, category.File is the generic icon
+	Extensions map[string]string // icons by lowercase extension, including the dot
+	Names      map[string]string // icons by lowercase file name
+	LinkDir    string            // icon of links to directories
+	LinkArrow  string            // separates links from their targets
+}
 
-	".html": Html,
-	".htm":  Html,
-	".xml":  Xml,
-	".iml":  Xml,
+// Default is the name of the set used if none is chosen.
+const Default = "nerd-v3"
 
-	".log":  Log,
-	".json": "",
-	".epub": "",
+// Sets are the icon sets by name.
+var Sets = map[string]*Set{
+	"nerd-v3": NerdV3,
+	"nerd-v2": NerdV2,
+	"emoji":   Emoji,
+	"ascii":   ASCII,
+}
 
-	// Databases
-	".sqlite":  "",
-	".sqlite3": "",
+// Icon returns the icon of a file named name in category c. Pass an empty
+// name to look up the category alone, or an empty ext to skip the
+// extension.
+func (s *Set) Icon(name, ext string, c int) string {
+	if icon, ok := s.Names[strings.ToLower(name)]; ok && name != "" {
+		return icon
+	}
+	if icon, ok := s.Extensions[strings.ToLower(ext)]; ok && ext != "" {
+		return icon
+	}
+	if icon, ok := s.Categories[c]; ok {
+		return icon
+	}
+	return s.Categories[category.File]
+}
 
-	// Configurations
-	".vim": "",
+// replace returns a copy of s with every icon in glyphs replaced.
+func (s *Set) replace(glyphs map[string]string) *Set {
+	swap := func(icon string) string {
+		if replacement, ok := glyphs[icon]; ok {
+			return replacement
+		}
+		return icon
+	}
+	swapAll := func(icons map[string]string) map[string]string {
+		result := make(map[string]string, len(icons))
+		for k, icon := range icons {
+			result[k] = swap(icon)
+		}
+		return result
+	}
+
+	categories := make(map[int]string, len(s.Categories))
+	for c, icon := range s.Categories {
+		categories[c] = swap(icon)
+	}
+
+	return &Set{
+		Categories: categories,
+		Extensions: swapAll(s.Extensions),
+		Names:      swapAll(s.Names),
+		LinkDir:    swap(s.LinkDir),
+		LinkArrow:  swap(s.LinkArrow),
+	}
 }
@@ -0,0 +1,80 @@
+package icons
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/operatios/lsg/category"
+)
+
+func TestSetsCoverEveryCategory(t *testing.T) {
+	for name, set := range Sets {
+		for c := range category.Names {
+			want := set.Categories[c]
+			if want == "" {
+				t.Errorf("%s: no icon for category %s", name, category.Names[c])
+				continue
+			}
+			if got := set.Icon("", "", c); got != want {
+				t.Errorf("%s: category %s resolves to %q, want %q", name, category.Names[c], got, want)
+			}
+		}
+	}
+}
+
+func TestSetsResolveEveryExtension(t *testing.T) {
+	for name, set := range Sets {
+		for ext, c := range category.Extensions {
+			want, ok := set.Extensions[strings.ToLower(ext)]
+			if !ok {
+				want = set.Categories[c]
+			}
+			if got := set.Icon("file"+ext, ext, c); got != want {
+				t.Errorf("%s: %s resolves to %q, want %q of category %s", name, ext, got, want, category.Names[c])
+			}
+		}
+
+		// Unknown extensions fall back to the icon of their category.
+		for c := range category.Names {
+			if got, want := set.Icon("file.unknown", ".unknown", c), set.Categories[c]; got != want {
+				t.Errorf("%s: unknown extension in category %s resolves to %q, want %q", name, category.Names[c], got, want)
+			}
+		}
+	}
+}
+
+func TestSetsResolveEveryName(t *testing.T) {
+	for name, set := range Sets {
+		for file, want := range set.Names {
+			if got := set.Icon(file, ".unknown", category.File); got != want {
+				t.Errorf("%s: %s resolves to %q, want %q", name, file, got, want)
+			}
+		}
+		if set.LinkDir == "" || set.LinkArrow == "" {
+			t.Errorf("%s: no link icons", name)
+		}
+	}
+}
+
+// Nerd Fonts v3 removed the Material Design Icons at U+F500 to U+FD46.
+func TestNerdV3HasNoRemovedGlyphs(t *testing.T) {
+	check := func(what, icon string) {
+		for _, r := range icon {
+			if r >= 0xF500 && r <= 0xFD46 {
+				t.Errorf("%s: icon %q uses removed codepoint U+%04X", what, icon, r)
+			}
+		}
+	}
+
+	for c, icon := range NerdV3.Categories {
+		check("category "+category.Names[c], icon)
+	}
+	for ext, icon := range NerdV3.Extensions {
+		check("extension "+ext, icon)
+	}
+	for name, icon := range NerdV3.Names {
+		check("name "+name, icon)
+	}
+	check("link to directory", NerdV3.LinkDir)
+	check("link arrow", NerdV3.LinkArrow)
+}
@@ -0,0 +1,57 @@
+package ls
+
+import "golang.org/x/sys/unix"
+
+// Inode flags as returned by FS_IOC_GETFLAGS, see ioctl_iflags(2).
+const (
+	fsComprFl     = 0x00000004
+	fsImmutableFl = 0x00000010
+	fsAppendFl    = 0x00000020
+	fsNodumpFl    = 0x00000040
+	fsEncryptFl   = 0x00000800
+	fsNocowFl     = 0x00800000
+	fsDaxFl       = 0x02000000
+)
+
+// inodeFlags are the shown flags in the order lsattr prints them.
+var inodeFlags = []struct {
+	flag   uint32
+	letter byte
+}{
+	{fsImmutableFl, 'i'},
+	{fsAppendFl, 'a'},
+	{fsNodumpFl, 'd'},
+	{fsComprFl, 'c'},
+	{fsEncryptFl, 'E'},
+	{fsNocowFl, 'C'},
+	{fsDaxFl, 'x'},
+}
+
+// attributes returns the inode flags of a regular file or directory the
+// way lsattr does, e.g. "i-d----", and whether the file is immutable. It
+// returns "" if the flags can not be read.
+func (f File) attributes() (string, bool) {
+	if f.fsys != OS || !f.info.Mode().IsRegular() && !f.info.IsDir() {
+		return "", false
+	}
+
+	fd, err := unix.Open(f.path, unix.O_RDONLY|unix.O_NONBLOCK|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return "", false
+	}
+	defer unix.Close(fd)
+
+	flags, err := unix.IoctlGetUint32(fd, unix.FS_IOC_GETFLAGS)
+	if err != nil {
+		return "", false
+	}
+
+	attrs := make([]byte, len(inodeFlags))
+	for i, inodeFlag := range inodeFlags {
+		attrs[i] = '-'
+		if flags&inodeFlag.flag != 0 {
+			attrs[i] = inodeFlag.letter
+		}
+	}
+	return string(attrs), flags&fsImmutableFl != 0
+}
@@ -0,0 +1,8 @@
+// +build !linux
+
+package ls
+
+// attributes returns "": inode flags are only read on Linux.
+func (f File) attributes() (string, bool) {
+	return "", false
+}
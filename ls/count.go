@@ -0,0 +1,94 @@
+package ls
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+)
+
+// maxCounters is how many directories countEntries reads at once.
+const maxCounters = 16
+
+// countEntries counts the entries of the directories among files that are
+// not counted yet, several directories at a time. Only names are read, not
+// the file information of every entry. Names starting with a dot are left
+// out unless hidden is set.
+func countEntries(files []File, hidden bool) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxCounters)
+
+	for i := range files {
+		file := &files[i]
+		if file.counted || !file.IsDir() || file.IsLink() {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			n, err := countNames(file.fsys, file.path, hidden)
+			if err != nil {
+				n = -1
+			}
+			file.entries, file.counted = n, true
+		}()
+	}
+	wg.Wait()
+}
+
+// countNames returns the number of entries of the directory at path.
+func countNames(fsys FS, path string, hidden bool) (int, error) {
+	count := func(names []string) int {
+		n := 0
+		for _, name := range names {
+			if hidden || !strings.HasPrefix(name, ".") {
+				n++
+			}
+		}
+		return n
+	}
+
+	if fsys != OS {
+		entries, err := fs.ReadDir(fsys, path)
+		if err != nil {
+			return 0, err
+		}
+
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name()
+		}
+		return count(names), nil
+	}
+
+	dir, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer dir.Close()
+
+	n := 0
+	for {
+		names, err := dir.Readdirnames(1024)
+		n += count(names)
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// entryCount returns the number of entries of a counted directory, or -1.
+func (f File) entryCount() int64 {
+	if !f.counted {
+		return -1
+	}
+	return int64(f.entries)
+}
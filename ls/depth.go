@@ -0,0 +1,121 @@
+package ls
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/operatios/lsg/category"
+)
+
+// ColorDepth is how many colors a terminal can show.
+type ColorDepth int
+
+const (
+	TrueColor  ColorDepth = iota // 24-bit colors, which Themes are made of
+	Colors256                    // the xterm palette of 256 colors
+	Colors16                     // the 16 ANSI colors
+	Monochrome                   // no colors, only bold, underline and reverse video
+)
+
+// ParseColorDepth parses "truecolor" (or "24bit"), "256", "16" or "mono".
+func ParseColorDepth(s string) (ColorDepth, error) {
+	switch strings.ToLower(s) {
+	case "truecolor", "24bit":
+		return TrueColor, nil
+	case "256":
+		return Colors256, nil
+	case "16":
+		return Colors16, nil
+	case "mono", "monochrome":
+		return Monochrome, nil
+	}
+	return 0, fmt.Errorf("invalid color depth: %s", s)
+}
+
+// WithDepth returns a copy of t with every color replaced by the nearest one
+// available at depth. Without colors, directories and executables are bold
+// and links underlined, so that they still stand out.
+func (t *Theme) WithDepth(depth ColorDepth) *Theme {
+	theme := *t
+	theme.depth = depth
+
+	if depth == Monochrome {
+		theme.ec = make(map[int]*color.RGBStyle, len(t.ec))
+		for c, style := range t.ec {
+			theme.ec[c] = style
+		}
+
+		fg := color.RGB(0, 0, 0)
+		theme.ec[category.Dir] = color.NewRGBStyle(fg).AddOpts(color.OpBold)
+		theme.ec[category.Executable] = color.NewRGBStyle(fg).AddOpts(color.OpBold)
+		theme.ec[category.Symlink] = color.NewRGBStyle(fg).AddOpts(color.OpUnderscore)
+		theme.ec[category.Broken] = color.NewRGBStyle(fg).AddOpts(color.OpUnderscore, color.OpReverse)
+	}
+	return &theme
+}
+
+type colorCode interface {
+	Code() string
+}
+
+func (t *Theme) sprint(c colorCode, a ...interface{}) string {
+	return color.RenderString(downsample(c.Code(), t.depth), fmt.Sprint(a...))
+}
+
+func (t *Theme) sprintf(c colorCode, format string, a ...interface{}) string {
+	return color.RenderString(downsample(c.Code(), t.depth), fmt.Sprintf(format, a...))
+}
+
+// downsample rewrites the 24-bit colors of an SGR code like "38;2;1;2;3;1"
+// to depth. In monochrome, colors are dropped and backgrounds become
+// reverse video.
+func downsample(code string, depth ColorDepth) string {
+	if depth == TrueColor {
+		return code
+	}
+
+	params := strings.Split(code, ";")
+	var result []string
+
+	for i := 0; i < len(params); i++ {
+		n, _ := strconv.Atoi(params[i])
+
+		switch {
+		case (n == 38 || n == 48) && i+4 < len(params) && params[i+1] == "2":
+			isBg := n == 48
+			var rgb [3]uint8
+			for j := range rgb {
+				v, _ := strconv.Atoi(params[i+2+j])
+				rgb[j] = uint8(v)
+			}
+			i += 4
+
+			switch depth {
+			case Colors256:
+				result = append(result, strconv.Itoa(n), "5", strconv.Itoa(int(color.Rgb2short(rgb[0], rgb[1], rgb[2]))))
+			case Colors16:
+				result = append(result, strconv.Itoa(int(color.RgbToAnsi(rgb[0], rgb[1], rgb[2], isBg))))
+			case Monochrome:
+				if isBg {
+					result = append(result, "7")
+				}
+			}
+
+		case depth == Monochrome && (n == 38 || n == 48) && i+2 < len(params) && params[i+1] == "5":
+			if n == 48 {
+				result = append(result, "7")
+			}
+			i += 2
+
+		case depth == Monochrome && (30 <= n && n <= 39 || 90 <= n && n <= 97):
+		case depth == Monochrome && (40 <= n && n <= 47 || 100 <= n && n <= 107):
+			result = append(result, "7")
+
+		default:
+			result = append(result, params[i])
+		}
+	}
+	return strings.Join(result, ";")
+}
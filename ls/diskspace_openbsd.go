@@ -0,0 +1,18 @@
+package ls
+
+import "golang.org/x/sys/unix"
+
+// diskSpace returns the space available to unprivileged users and the size
+// of the file system holding path, in bytes.
+func diskSpace(path string) (free, total uint64, err error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, 0, err
+	}
+
+	avail := st.F_bavail
+	if avail < 0 {
+		avail = 0
+	}
+	return uint64(avail) * uint64(st.F_bsize), st.F_blocks * uint64(st.F_bsize), nil
+}
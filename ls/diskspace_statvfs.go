@@ -0,0 +1,15 @@
+// +build netbsd solaris
+
+package ls
+
+import "golang.org/x/sys/unix"
+
+// diskSpace returns the space available to unprivileged users and the size
+// of the file system holding path, in bytes.
+func diskSpace(path string) (free, total uint64, err error) {
+	var st unix.Statvfs_t
+	if err := unix.Statvfs(path, &st); err != nil {
+		return 0, 0, err
+	}
+	return st.Bavail * uint64(st.Frsize), st.Blocks * uint64(st.Frsize), nil
+}
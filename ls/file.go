@@ -0,0 +1,309 @@
+package ls
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/operatios/lsg/category"
+	"github.com/operatios/lsg/icons"
+)
+
+// File is a single directory entry.
+type File struct {
+	fsys FS
+	info fs.FileInfo
+	path string
+	root string // directory absolute link targets are shown relative to
+	name string // shown instead of the base name if set
+
+	entries int  // number of directory entries, -1 if they could not be read
+	counted bool // whether entries is set, see countEntries
+}
+
+// NewFile returns the File at path in fsys. A symbolic link is not followed.
+func NewFile(fsys FS, path string) (File, error) {
+	fileInfo, err := fsys.Lstat(path)
+
+	// File got deleted while executing
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{fsys: fsys, info: fileInfo, path: path}, nil
+}
+
+// Name returns the base name of the file, or the path it was named by on
+// the command line.
+func (f File) Name() string {
+	if f.name != "" {
+		return f.name
+	}
+	return f.info.Name()
+}
+
+// Path returns the path the file was listed under.
+func (f File) Path() string {
+	return f.path
+}
+
+// Info returns the lstat information of the file.
+func (f File) Info() fs.FileInfo {
+	return f.info
+}
+
+func (f File) ext() string {
+	return filepath.Ext(f.Name())
+}
+
+// Size returns the length in bytes.
+func (f File) Size() int64 {
+	return f.info.Size()
+}
+
+// displaySize returns the allocated size with Options.Blocks, else Size.
+func (f File) displaySize(opts Options) int64 {
+	if opts.Blocks {
+		return f.allocated()
+	}
+	return f.Size()
+}
+
+func (f File) fileMode() string {
+	return f.info.Mode().String()
+}
+
+// octalMode returns the permissions and setuid, setgid and sticky bits
+// in octal, e.g. "4755".
+func (f File) octalMode() string {
+	mode := f.info.Mode()
+	bits := uint32(mode.Perm())
+
+	if mode&fs.ModeSetuid != 0 {
+		bits |= 0o4000
+	}
+	if mode&fs.ModeSetgid != 0 {
+		bits |= 0o2000
+	}
+	if mode&fs.ModeSticky != 0 {
+		bits |= 0o1000
+	}
+	return fmt.Sprintf("%04o", bits)
+}
+
+// isDirTarget reports whether the file is a directory or a link to one.
+func (f File) isDirTarget() bool {
+	if f.IsDir() {
+		return true
+	}
+	if !f.IsLink() {
+		return false
+	}
+	info, err := stat(f.fsys, f.path)
+	return err == nil && info.IsDir()
+}
+
+// IsLink reports whether the file is a symbolic link.
+func (f File) IsLink() bool {
+	return f.info.Mode()&fs.ModeSymlink != 0
+}
+
+// IsBroken reports whether the file is a link pointing to nothing.
+func (f File) IsBroken() bool {
+	_, err := stat(f.fsys, f.path)
+	return err != nil
+}
+
+// Target returns the destination of a link. Absolute destinations below the
+// listing root are shown relative to it.
+func (f File) Target() string {
+	target, _ := f.fsys.Readlink(f.path)
+
+	p := pathsOf(f.fsys)
+	root := f.root
+	if root == "" {
+		root, _ = p.abs(".")
+	}
+	relPath, _ := p.rel(root, target)
+
+	if relPath != "" && !strings.HasPrefix(relPath, "..") {
+		return relPath
+	}
+	return target
+}
+
+// pretty returns the icon, name, classify indicator and link target of the
+// file as they are displayed.
+func (f File) pretty(opts Options) string {
+	name, target := f.prettyParts(opts, 0)
+	return name + target
+}
+
+// prettyParts is pretty split into the name and, for links, the arrow and
+// target. With Options.Truncate and a width above 0, both are shortened to
+// fit into width columns, the name first.
+func (f File) prettyParts(opts Options, width int) (name, target string) {
+	displayName := f.Name()
+	showTarget := !opts.NoTargets && f.IsLink()
+
+	var suffix, arrow, targetName, targetSuffix string
+	if showTarget {
+		if opts.NoIcons {
+			arrow = "->"
+		} else {
+			arrow = opts.icons().LinkArrow
+		}
+		targetName = f.Target()
+
+		if opts.Classify {
+			if info, err := stat(f.fsys, f.path); err == nil {
+				targetSuffix = indicator(info.Mode(), false)
+			}
+		}
+	} else if opts.Classify {
+		suffix = indicator(f.info.Mode(), f.isDoor())
+	}
+
+	var icon string
+	if !opts.NoIcons {
+		icon = f.icon(opts.icons()) + " "
+	}
+
+	if width > 0 && opts.truncates() {
+		over := textWidth(icon+displayName+suffix+targetName+targetSuffix) - width
+		if showTarget {
+			over += textWidth(arrow) + 2
+		}
+
+		displayName, over = shorten(displayName, over, opts.Truncate)
+		if showTarget {
+			targetName, _ = shorten(targetName, over, opts.Truncate)
+		}
+	}
+
+	name = icon + displayName + suffix
+	if showTarget {
+		target = " " + arrow + " " + targetName + targetSuffix
+	}
+	return name, target
+}
+
+// indicator returns the -F suffix of a file with mode.
+func indicator(mode fs.FileMode, door bool) string {
+	switch {
+	case mode.IsDir():
+		return "/"
+	case mode&fs.ModeSymlink != 0:
+		return "@"
+	case mode&fs.ModeNamedPipe != 0:
+		return "|"
+	case mode&fs.ModeSocket != 0:
+		return "="
+	case door:
+		return ">"
+	case mode.IsRegular() && mode&0o111 != 0:
+		return "*"
+	}
+	return ""
+}
+
+// Category returns one of the category constants.
+func (f File) Category() int {
+	if f.IsLink() {
+		if f.IsBroken() {
+			return category.Broken
+		}
+		return category.Symlink
+	}
+
+	if f.IsDir() {
+		if perm := f.permCategory(); perm >= 0 {
+			return perm
+		}
+		return category.Dir
+	}
+
+	if special := f.special(); special >= 0 {
+		return special
+	}
+
+	if perm := f.permCategory(); perm >= 0 {
+		return perm
+	}
+
+	if extCategory, ok := category.Extensions[f.ext()]; ok {
+		return extCategory
+	}
+
+	return category.File
+}
+
+// modeCategory returns the category of a regular file or directory with
+// mode that stands out by its permissions, or -1.
+func modeCategory(mode fs.FileMode) int {
+	if mode.IsDir() {
+		otherWritable := mode&0o002 != 0
+		sticky := mode&fs.ModeSticky != 0
+
+		switch {
+		case otherWritable && sticky:
+			return category.StickyOtherWritable
+		case otherWritable:
+			return category.OtherWritable
+		case sticky:
+			return category.Sticky
+		}
+		return -1
+	}
+
+	switch {
+	case !mode.IsRegular():
+		return -1
+	case mode&fs.ModeSetuid != 0:
+		return category.Setuid
+	case mode&fs.ModeSetgid != 0:
+		return category.Setgid
+	case mode&0o111 != 0:
+		return category.Executable
+	}
+	return -1
+}
+
+// special returns the category of a file that is neither regular nor a
+// directory or link, or -1.
+func (f File) special() int {
+	mode := f.info.Mode()
+
+	switch {
+	case mode&fs.ModeNamedPipe != 0:
+		return category.Fifo
+	case mode&fs.ModeSocket != 0:
+		return category.Socket
+	case mode&fs.ModeCharDevice != 0:
+		return category.CharDevice
+	case mode&fs.ModeDevice != 0:
+		return category.BlockDevice
+	case f.isDoor():
+		return category.Door
+	}
+	return -1
+}
+
+// icon returns the icon of the file in set. Links and files standing out by
+// their type or set-user/group-ID bit get the icon of their category, the
+// others are looked up by name and extension first.
+func (f File) icon(set *icons.Set) string {
+	c := f.Category()
+	name := filepath.Base(f.Name())
+
+	switch {
+	case f.IsLink() && f.isDirTarget():
+		return set.LinkDir
+	case f.IsLink() || f.special() >= 0 || c == category.Setuid || c == category.Setgid:
+		return set.Icon("", "", c)
+	case f.IsDir():
+		return set.Icon(name, "", c)
+	}
+	return set.Icon(name, f.ext(), c)
+}
@@ -0,0 +1,110 @@
+// +build darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package ls
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// IsDir reports whether the file is a directory.
+func (f File) IsDir() bool {
+	return f.info.IsDir()
+}
+
+// IsHidden reports whether the file is a dotfile.
+func (f File) IsHidden() bool {
+	return f.Name()[0] == '.'
+}
+
+func (f File) stat_t() (*syscall.Stat_t, bool) {
+	stat, ok := f.info.Sys().(*syscall.Stat_t)
+	return stat, ok
+}
+
+func (f File) group() string {
+	stat, ok := f.stat_t()
+	if !ok {
+		return ""
+	}
+
+	group, err := user.LookupGroupId(fmt.Sprint(stat.Gid))
+	if err != nil {
+		log.Panic(err)
+	}
+	return group.Name
+}
+
+func (f File) owner() string {
+	stat, ok := f.stat_t()
+	if !ok {
+		return ""
+	}
+
+	u, err := user.LookupId(fmt.Sprint(stat.Uid))
+	if err != nil {
+		log.Panic(err)
+	}
+	return u.Username
+}
+
+// permCategory returns the category of a file that stands out by its
+// permissions, or -1.
+func (f File) permCategory() int {
+	return modeCategory(f.info.Mode())
+}
+
+// access returns what the current user may do with the file, following
+// links, as "rwx" with "-" for denied permissions. The answer comes from
+// access(2), so group membership and root are accounted for. For files of
+// other file systems than OS it returns "???".
+func (f File) access() string {
+	if f.fsys != OS {
+		return "???"
+	}
+
+	perms := []byte("---")
+	for i, mode := range []uint32{unix.R_OK, unix.W_OK, unix.X_OK} {
+		if unix.Access(f.path, mode) == nil {
+			perms[i] = "rwx"[i]
+		}
+	}
+	return string(perms)
+}
+
+// isDoor reports whether the file is a Solaris door (S_IFDOOR).
+func (f File) isDoor() bool {
+	stat, ok := f.stat_t()
+	return ok && uint32(stat.Mode)&syscall.S_IFMT == 0xd000
+}
+
+// device returns the major and minor number of a device file.
+func (f File) device() (uint32, uint32, bool) {
+	stat, ok := f.stat_t()
+	if !ok || f.info.Mode()&os.ModeDevice == 0 {
+		return 0, 0, false
+	}
+	return unix.Major(uint64(stat.Rdev)), unix.Minor(uint64(stat.Rdev)), true
+}
+
+func (f File) nLink() uint {
+	stat, ok := f.stat_t()
+	if !ok {
+		return 1
+	}
+	return uint(stat.Nlink)
+}
+
+// allocated returns the space the file takes up on disk.
+func (f File) allocated() int64 {
+	stat, ok := f.stat_t()
+	if !ok {
+		return f.Size()
+	}
+	return int64(stat.Blocks) * 512
+}
@@ -0,0 +1,101 @@
+package ls
+
+import (
+	"log"
+	"strings"
+	"syscall"
+
+	"github.com/operatios/lsg/category"
+)
+
+func (f File) attrs() uint32 {
+	data, ok := f.info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return 0
+	}
+	return data.FileAttributes
+}
+
+// IsDir reports whether the file is a directory or a junction.
+func (f File) IsDir() bool {
+	if f.IsLink() {
+		return f.attrs()&syscall.FILE_ATTRIBUTE_DIRECTORY != 0
+	}
+	return f.info.IsDir()
+}
+
+// IsHidden reports whether the file is a dotfile or has the hidden attribute.
+func (f File) IsHidden() bool {
+	if f.Name()[0] == '.' {
+		return true
+	}
+	return f.attrs()&syscall.FILE_ATTRIBUTE_HIDDEN != 0
+}
+
+// permCategory returns -1: Windows has no unix permission bits, every
+// directory would look other-writable.
+func (f File) permCategory() int {
+	return -1
+}
+
+// access returns what the current user may do with the file as "rwx" with
+// "-" for denied permissions, judged by the read-only attribute and the
+// extension.
+func (f File) access() string {
+	perms := []byte("r--")
+	if f.info.Mode()&0o200 != 0 {
+		perms[1] = 'w'
+	}
+	if f.IsDir() || category.Extensions[strings.ToLower(f.ext())] == category.Executable {
+		perms[2] = 'x'
+	}
+	return string(perms)
+}
+
+func (f File) isDoor() bool {
+	return false
+}
+
+func (f File) device() (uint32, uint32, bool) {
+	return 0, 0, false
+}
+
+func (f File) nLink() uint {
+	if f.fsys != OS {
+		return 1
+	}
+
+	h, err := syscall.CreateFile(
+		syscall.StringToUTF16Ptr(f.path),
+		0,
+		0,
+		nil,
+		syscall.OPEN_EXISTING,
+		syscall.FILE_FLAG_OPEN_REPARSE_POINT|syscall.FILE_FLAG_BACKUP_SEMANTICS,
+		0)
+	defer syscall.Close(h)
+
+	if err != nil {
+		log.Panic(err)
+	}
+
+	var info syscall.ByHandleFileInformation
+	err = syscall.GetFileInformationByHandle(h, &info)
+
+	if err != nil {
+		log.Panic(err)
+	}
+	return uint(info.NumberOfLinks)
+}
+
+func (f File) owner() string {
+	return ""
+}
+
+func (f File) group() string {
+	return ""
+}
+
+func (f File) allocated() int64 {
+	return f.Size()
+}
@@ -0,0 +1,128 @@
+package ls_test
+
+import (
+	"io/fs"
+	"path"
+	"testing/fstest"
+	"time"
+
+	"github.com/operatios/lsg/ls"
+)
+
+// memFS is an ls.FS over a fstest.MapFS. Files with fs.ModeSymlink are
+// links to the path held in their Data. Files in sizes report that size
+// without holding their contents.
+type memFS struct {
+	fstest.MapFS
+	sizes map[string]int64
+}
+
+type sizedInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+func (s sizedInfo) Size() int64 { return s.size }
+
+type sizedEntry struct {
+	fs.DirEntry
+	size int64
+}
+
+func (s sizedEntry) Info() (fs.FileInfo, error) {
+	info, err := s.DirEntry.Info()
+	if err != nil {
+		return nil, err
+	}
+	return sizedInfo{info, s.size}, nil
+}
+
+func (m memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := m.MapFS.ReadDir(name)
+	for i, entry := range entries {
+		if size, ok := m.sizes[path.Join(name, entry.Name())]; ok {
+			entries[i] = sizedEntry{entry, size}
+		}
+	}
+	return entries, err
+}
+
+func (m memFS) Lstat(name string) (fs.FileInfo, error) {
+	if name == "." {
+		return fs.Stat(m.MapFS, name)
+	}
+
+	entries, err := fs.ReadDir(m, path.Dir(name))
+	if err == nil {
+		for _, entry := range entries {
+			if entry.Name() == path.Base(name) {
+				return entry.Info()
+			}
+		}
+	}
+	return nil, &fs.PathError{Op: "lstat", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m memFS) Readlink(name string) (string, error) {
+	file, ok := m.MapFS[name]
+	if !ok || file.Mode&fs.ModeSymlink == 0 {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+	return string(file.Data), nil
+}
+
+var modTime = time.Date(2021, time.May, 21, 12, 30, 0, 0, time.UTC)
+
+// file, link and dir build the entries of fixture trees. Ages count back
+// from modTime.
+func file(size int, mode fs.FileMode, age time.Duration) *fstest.MapFile {
+	return &fstest.MapFile{Data: make([]byte, size), Mode: mode, ModTime: modTime.Add(-age)}
+}
+
+func link(target string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(target), Mode: fs.ModeSymlink | 0o777, ModTime: modTime}
+}
+
+func dir(age time.Duration) *fstest.MapFile {
+	return &fstest.MapFile{Mode: fs.ModeDir | 0o755, ModTime: modTime.Add(-age)}
+}
+
+// intp returns a pointer to n, for the optional numbers of Options.
+func intp(n int) *int {
+	return &n
+}
+
+// clock returns an Options.Now reporting modTime in loc.
+func clock(loc *time.Location) func() time.Time {
+	return func() time.Time { return modTime.In(loc) }
+}
+
+// testFS returns a small tree with hidden files, nested directories and
+// links, one of them broken.
+func testFS() ls.FS {
+	return memFS{MapFS: fstest.MapFS{
+		"a.txt":             file(5, 0o644, time.Hour),
+		"B.go":              file(300, 0o644, 2*time.Hour),
+		"file2":             file(20, 0o644, 0),
+		"file10":            file(10, 0o644, 3*time.Hour),
+		".hidden":           file(1, 0o644, 0),
+		"dir":               dir(0),
+		"dir/x.md":          file(2, 0o644, 0),
+		"dir/sub":           dir(0),
+		"dir/sub/deep.txt":  file(3, 0o644, 0),
+		"dir/.secret":       dir(0),
+		"dir/.secret/k.txt": file(4, 0o644, 0),
+		"empty":             dir(0),
+		"link":              link("a.txt"),
+		"broken":            link("missing"),
+	}}
+}
+
+// names returns the names of files.
+func names(files []ls.File) []string {
+	result := make([]string, len(files))
+	for i, file := range files {
+		result[i] = file.Name()
+	}
+	return result
+}
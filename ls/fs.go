@@ -0,0 +1,194 @@
+package ls
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v2"
+)
+
+// FS is a file system that can be listed. Besides reading directories, it
+// has to describe links without following them.
+type FS interface {
+	fs.FS
+	Lstat(name string) (fs.FileInfo, error)
+	Readlink(name string) (string, error)
+}
+
+// OS is the file system of the operating system. Unlike os.DirFS, it accepts
+// any path the os package accepts, including absolute ones and "..".
+var OS FS = osFS{}
+
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error)          { return os.Open(name) }
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (osFS) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
+func (osFS) Lstat(name string) (fs.FileInfo, error)     { return os.Lstat(name) }
+func (osFS) Readlink(name string) (string, error)       { return os.Readlink(name) }
+
+func (osFS) join(elem ...string) string              { return filepath.Join(elem...) }
+func (osFS) dir(name string) string                  { return filepath.Dir(name) }
+func (osFS) isAbs(name string) bool                  { return filepath.IsAbs(name) }
+func (osFS) abs(name string) (string, error)         { return filepath.Abs(name) }
+func (osFS) rel(base, target string) (string, error) { return filepath.Rel(base, target) }
+func (osFS) separator() string                       { return string(filepath.Separator) }
+
+func (osFS) resolve(name, target string) string {
+	if filepath.IsAbs(target) {
+		return target
+	}
+	return filepath.Join(filepath.Dir(name), target)
+}
+
+// paths manipulates the names of a file system. The OS uses the conventions
+// of the operating system, any other FS the slash-separated names of io/fs.
+type paths interface {
+	join(elem ...string) string
+	dir(name string) string
+	isAbs(name string) bool
+	abs(name string) (string, error)
+	rel(base, target string) (string, error)
+	separator() string
+
+	// resolve returns the name the link at name pointing to target refers to.
+	resolve(name, target string) string
+}
+
+func pathsOf(fsys FS) paths {
+	if p, ok := fsys.(paths); ok {
+		return p
+	}
+	return slashPaths{}
+}
+
+// slashPaths are the names of an fs.FS. Its root counts as "/", so that
+// absolute link targets can be shown relative to the listing root.
+type slashPaths struct{}
+
+func (slashPaths) join(elem ...string) string { return path.Join(elem...) }
+func (slashPaths) dir(name string) string     { return path.Dir(name) }
+func (slashPaths) isAbs(name string) bool     { return path.IsAbs(name) }
+func (slashPaths) separator() string          { return "/" }
+
+func (slashPaths) abs(name string) (string, error) {
+	return path.Join("/", name), nil
+}
+
+func (slashPaths) resolve(name, target string) string {
+	if path.IsAbs(target) {
+		if target = path.Clean(target); target == "/" {
+			return "."
+		}
+		return target[1:]
+	}
+	return path.Join(path.Dir(name), target)
+}
+
+func (slashPaths) rel(base, target string) (string, error) {
+	if !path.IsAbs(base) || !path.IsAbs(target) {
+		return "", errors.New("rel: paths must both be absolute")
+	}
+	base, target = path.Clean(base), path.Clean(target)
+	if target == base {
+		return ".", nil
+	}
+	if prefix := strings.TrimSuffix(base, "/") + "/"; strings.HasPrefix(target, prefix) {
+		return target[len(prefix):], nil
+	}
+	return "", errors.New("rel: " + target + " is not below " + base)
+}
+
+// WrapFS returns an FS listing fsys. Lstat and Readlink (or ReadLink) are
+// used if fsys has them; otherwise fsys is treated as having no links.
+func WrapFS(fsys fs.FS) FS {
+	if f, ok := fsys.(FS); ok {
+		return f
+	}
+	return wrappedFS{fsys}
+}
+
+type wrappedFS struct {
+	fs.FS
+}
+
+func (w wrappedFS) Lstat(name string) (fs.FileInfo, error) {
+	if f, ok := w.FS.(interface {
+		Lstat(name string) (fs.FileInfo, error)
+	}); ok {
+		return f.Lstat(name)
+	}
+	return fs.Stat(w.FS, name)
+}
+
+func (w wrappedFS) Readlink(name string) (string, error) {
+	switch f := w.FS.(type) {
+	case interface{ Readlink(string) (string, error) }:
+		return f.Readlink(name)
+	case interface{ ReadLink(string) (string, error) }:
+		return f.ReadLink(name)
+	}
+	return "", &fs.PathError{Op: "readlink", Path: name, Err: errors.New("not supported")}
+}
+
+// stat returns the information about name, following links.
+func stat(fsys FS, name string) (fs.FileInfo, error) {
+	if fsys == OS {
+		return os.Stat(name)
+	}
+
+	for hops := 0; hops < 40; hops++ {
+		info, err := fsys.Lstat(name)
+		if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+			return info, err
+		}
+
+		target, err := fsys.Readlink(name)
+		if err != nil {
+			return nil, err
+		}
+		name = pathsOf(fsys).resolve(name, target)
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: errors.New("too many levels of symbolic links")}
+}
+
+// globFS adapts an FS to the interface doublestar globs on.
+type globFS struct {
+	fsys FS
+}
+
+func (g globFS) Lstat(name string) (os.FileInfo, error) { return g.fsys.Lstat(name) }
+func (g globFS) Stat(name string) (os.FileInfo, error)  { return stat(g.fsys, name) }
+func (g globFS) Open(name string) (doublestar.File, error) {
+	entries, err := fs.ReadDir(g.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return globDir(entries), nil
+}
+
+func (g globFS) PathSeparator() rune {
+	if g.fsys == OS {
+		return os.PathSeparator
+	}
+	return '/'
+}
+
+type globDir []fs.DirEntry
+
+func (d globDir) Close() error { return nil }
+func (d globDir) Readdir(int) ([]os.FileInfo, error) {
+	infos := make([]os.FileInfo, 0, len(d))
+	for _, entry := range d {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
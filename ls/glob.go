@@ -0,0 +1,190 @@
+package ls
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/bmatcuk/doublestar/v2"
+)
+
+// ErrNoMatch is returned by the globs of a Lister if no file is left to list.
+var ErrNoMatch = errors.New("no matches")
+
+// Glob returns the names of all files in the operating system matching
+// pattern. Besides "*", "?" and "[classes]", a pattern may contain "**" to
+// match any number of directories and "{a,b}" to match either alternative.
+func Glob(pattern string) ([]string, error) {
+	return glob(OS, pattern, false)
+}
+
+func glob(fsys FS, pattern string, ignoreCase bool) ([]string, error) {
+	gfs := globFS{fsys}
+
+	pattern, err := normalizePattern(pattern, ignoreCase, gfs.PathSeparator() != '\\')
+	if err != nil {
+		return nil, err
+	}
+
+	return doublestar.GlobOS(gfs, pattern)
+}
+
+// excluded reports whether name matches one of the patterns.
+func excluded(fsys FS, patterns []string, name string, ignoreCase bool) bool {
+	gfs := globFS{fsys}
+
+	for _, pattern := range patterns {
+		pattern, err := normalizePattern(pattern, ignoreCase, gfs.PathSeparator() != '\\')
+		if err != nil {
+			continue
+		}
+
+		if ok, _ := doublestar.PathMatchOS(gfs, pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPattern reports whether s contains any glob syntax.
+func IsPattern(s string) bool {
+	return strings.ContainsAny(s, "*?[{")
+}
+
+// noMatch returns the error of pattern matching nothing.
+func noMatch(pattern string) error {
+	return fmt.Errorf("%w for %s", ErrNoMatch, pattern)
+}
+
+// normalizePattern checks the syntax of pattern and rewrites it into the
+// dialect doublestar understands: "[!...]" becomes "[^...]" and, with
+// ignoreCase, every letter matches both of its cases.
+func normalizePattern(pattern string, ignoreCase, escape bool) (string, error) {
+	var b strings.Builder
+	runes := []rune(pattern)
+	braces := 0
+
+	bad := func(reason string) (string, error) {
+		return "", fmt.Errorf("bad pattern %q: %s: %w", pattern, reason, doublestar.ErrBadPattern)
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case escape && r == '\\':
+			if i+1 == len(runes) {
+				return bad("trailing backslash")
+			}
+			i++
+			b.WriteRune('\\')
+			b.WriteRune(runes[i])
+
+		case r == '[':
+			end := classEnd(runes, i+1, escape)
+			if end < 0 {
+				return bad("unclosed [")
+			}
+
+			class := append([]rune(nil), runes[i+1:end]...)
+			if len(class) > 0 && class[0] == '!' {
+				class[0] = '^'
+			}
+			if len(class) == 0 || len(class) == 1 && class[0] == '^' {
+				return bad("empty character class")
+			}
+
+			b.WriteRune('[')
+			b.WriteString(string(class))
+			if ignoreCase {
+				b.WriteString(swapClassCase(class, escape))
+			}
+			b.WriteRune(']')
+			i = end
+
+		case r == '{':
+			braces++
+			b.WriteRune(r)
+
+		case r == '}':
+			if braces > 0 {
+				braces--
+			}
+			b.WriteRune(r)
+
+		case ignoreCase && unicode.ToLower(r) != unicode.ToUpper(r):
+			b.WriteRune('[')
+			b.WriteRune(unicode.ToLower(r))
+			b.WriteRune(unicode.ToUpper(r))
+			b.WriteRune(']')
+
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	if braces > 0 {
+		return bad("unclosed {")
+	}
+	return b.String(), nil
+}
+
+// classEnd returns the index of the "]" closing the class starting at i.
+func classEnd(runes []rune, i int, escape bool) int {
+	for ; i < len(runes); i++ {
+		switch {
+		case escape && runes[i] == '\\':
+			i++
+		case runes[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// swapClassCase returns the letters and letter ranges of a character class
+// in their other case.
+func swapClassCase(class []rune, escape bool) string {
+	var b strings.Builder
+
+	swap := func(r rune) rune {
+		if unicode.IsUpper(r) {
+			return unicode.ToLower(r)
+		}
+		return unicode.ToUpper(r)
+	}
+	isLetter := func(r rune) bool {
+		return unicode.ToLower(r) != unicode.ToUpper(r)
+	}
+
+	i := 0
+	if class[0] == '^' {
+		i++
+	}
+	for i < len(class) {
+		low := class[i]
+		i++
+		if escape && low == '\\' && i < len(class) {
+			low = class[i]
+			i++
+		}
+
+		high := low
+		if i+1 < len(class) && class[i] == '-' {
+			high = class[i+1]
+			i += 2
+		}
+
+		if !isLetter(low) || !isLetter(high) || unicode.IsUpper(low) != unicode.IsUpper(high) {
+			continue
+		}
+
+		b.WriteRune(swap(low))
+		if high != low {
+			b.WriteRune('-')
+			b.WriteRune(swap(high))
+		}
+	}
+	return b.String()
+}
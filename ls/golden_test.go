@@ -0,0 +1,194 @@
+package ls_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/operatios/lsg/ls"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/*.golden with the current output")
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	// Themed cases compare escape codes even though no terminal is attached.
+	color.ForceOpenColor()
+	os.Exit(m.Run())
+}
+
+// goldenFS returns the fixture tree the golden files are rendered from:
+// hidden files, unicode names, large files, executables and links to files
+// and directories, broken ones and a loop.
+func goldenFS() ls.FS {
+	return memFS{
+		MapFS: fstest.MapFS{
+			".config":                     dir(48 * time.Hour),
+			".config/settings.json":       file(220, 0o600, 48*time.Hour),
+			".env":                        file(42, 0o600, 24*time.Hour),
+			"Documents":                   dir(72 * time.Hour),
+			"Documents/naïve résumé.docx": file(18_432, 0o644, 72*time.Hour),
+			"Documents/report.pdf":        file(122_880, 0o644, 30*24*time.Hour),
+			"Music":                       dir(5 * time.Hour),
+			"Music/Ünïcödé – 曲.flac":      file(0, 0o644, 5*time.Hour),
+			"Videos":                      dir(time.Hour),
+			"Videos/archive.tar.gz":       file(0, 0o644, 365*24*time.Hour),
+			"Videos/holiday.mkv":          file(0, 0o644, time.Hour),
+			"café.md":                     file(1_337, 0o644, 10*time.Minute),
+			"dead":                        link("nowhere"),
+			"docs":                        link("Documents"),
+			"empty":                       dir(0),
+			"latest":                      link("Videos/holiday.mkv"),
+			"loop":                        link("loop"),
+			"run.sh":                      file(512, 0o755, 2*time.Hour),
+			"src":                         dir(3 * time.Hour),
+			"src/cmd/tool/main.go":        file(2_048, 0o644, 3*time.Hour),
+			"src/go.mod":                  file(64, 0o644, 3*time.Hour),
+			"src/lib.go":                  file(4_096, 0o644, 3*time.Hour),
+			"src/lib_test.go":             file(8_192, 0o644, 3*time.Hour),
+			"suid":                        file(16_384, fs.ModeSetuid|0o755, 6*time.Hour),
+			"日本語のファイル名.txt":               file(3, 0o644, 0),
+			"a very long file name that needs truncating.txt": file(1, 0o644, 0),
+		},
+		sizes: map[string]int64{
+			"Music/Ünïcödé – 曲.flac": 42 << 20,
+			"Videos/archive.tar.gz":  700 << 20,
+			"Videos/holiday.mkv":     3_435_973_837,
+		},
+	}
+}
+
+// renderDir lists dir and writes it with the renderer returned by newRenderer.
+func renderDir(dir string, newRenderer func(ls.Options) ls.Renderer) func(io.Writer, ls.Options) error {
+	return func(w io.Writer, opts ls.Options) error {
+		files, err := ls.NewLister(opts).List(dir)
+		if err != nil {
+			return err
+		}
+		return newRenderer(opts).Render(w, files)
+	}
+}
+
+// renderGlob writes the matches of pattern in grid blocks, each under a
+// "dir:" header like the command line does.
+func renderGlob(pattern string) func(io.Writer, ls.Options) error {
+	return func(w io.Writer, opts ls.Options) error {
+		blocks, err := ls.NewLister(opts).Glob(pattern)
+		if err != nil {
+			return err
+		}
+
+		for i, block := range blocks {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintln(w, block.Dir+":")
+			if err := ls.NewGrid(opts).Render(w, block.Files); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func renderTree(root string) func(io.Writer, ls.Options) error {
+	return func(w io.Writer, opts ls.Options) error {
+		return ls.NewTree(opts).RenderRoot(w, root)
+	}
+}
+
+func renderTreeGlob(pattern string) func(io.Writer, ls.Options) error {
+	return func(w io.Writer, opts ls.Options) error {
+		return ls.NewTree(opts).RenderGlob(w, pattern)
+	}
+}
+
+func grid(opts ls.Options) ls.Renderer { return ls.NewGrid(opts) }
+func long(opts ls.Options) ls.Renderer { return ls.NewLong(opts) }
+
+func TestGolden(t *testing.T) {
+	plain := ls.Options{NoIcons: true}
+
+	tests := []struct {
+		name   string
+		opts   ls.Options
+		render func(io.Writer, ls.Options) error
+	}{
+		{"grid-40", ls.Options{Width: 40, NoIcons: true}, renderDir(".", grid)},
+		{"grid-80", ls.Options{Width: 80, NoIcons: true}, renderDir(".", grid)},
+		{"grid-120-all", ls.Options{Width: 120, NoIcons: true, All: true}, renderDir(".", grid)},
+		{"grid-80-classify", ls.Options{Width: 80, NoIcons: true, Classify: true}, renderDir(".", grid)},
+		{"grid-80-across", ls.Options{Width: 80, NoIcons: true, Across: true}, renderDir(".", grid)},
+		{"grid-30-truncate", ls.Options{Width: 30, NoIcons: true, Truncate: "middle"}, renderDir(".", grid)},
+		{"grid-80-nerd", ls.Options{Width: 80}, renderDir(".", grid)},
+		{"grid-80-ascii", ls.Options{Width: 80, IconSet: "ascii"}, renderDir(".", grid)},
+		{"grid-80-emoji", ls.Options{Width: 80, IconSet: "emoji"}, renderDir(".", grid)},
+		{"grid-80-dark", ls.Options{Width: 80, Theme: ls.Dark}, renderDir(".", grid)},
+		{"grid-80-light", ls.Options{Width: 80, Theme: ls.Light}, renderDir(".", grid)},
+		{"grid-80-light-16", ls.Options{Width: 80, Theme: ls.Light.WithDepth(ls.Colors16)}, renderDir(".", grid)},
+		{"long", plain, renderDir(".", long)},
+		{"long-bytes", ls.Options{NoIcons: true, Bytes: true}, renderDir(".", long)},
+		{"long-bytes-extend", ls.Options{NoIcons: true, Bytes: true, Extend: true, Header: true}, renderDir(".", long)},
+		{"long-si-precision-2", ls.Options{NoIcons: true, SI: true, Precision: intp(2)}, renderDir(".", long)},
+		{"long-precision-0", ls.Options{NoIcons: true, Precision: intp(0)}, renderDir(".", long)},
+		{"long-block-size-k", ls.Options{NoIcons: true, BlockSize: "K", GroupDigits: true}, renderDir(".", long)},
+		{"long-entries", ls.Options{NoIcons: true, Entries: true, Sort: "entries"}, renderDir(".", long)},
+		{"long-octal", ls.Options{NoIcons: true, Octal: true}, renderDir(".", long)},
+		{"long-size-sort", ls.Options{NoIcons: true, Sort: "size", Header: true}, renderDir("Videos", long)},
+		{"long-summary", ls.Options{NoIcons: true, Summary: true}, renderDir(".", long)},
+		{"long-size-bar", ls.Options{NoIcons: true, SizeBar: 10}, renderDir(".", long)},
+		{"long-dark", ls.Options{Theme: ls.Dark, Extend: true}, renderDir(".", long)},
+		{"long-light", ls.Options{Theme: ls.Light, Extend: true}, renderDir(".", long)},
+		{"long-light-256", ls.Options{Theme: ls.Light.WithDepth(ls.Colors256), Extend: true}, renderDir(".", long)},
+		{"tree", plain, renderTree(".")},
+		{"tree-all-compact", ls.Options{NoIcons: true, All: true, Compact: true}, renderTree(".")},
+		{"tree-level-1", ls.Options{NoIcons: true, Level: 1}, renderTree(".")},
+		{"tree-summary", ls.Options{NoIcons: true, Summary: true}, renderTree(".")},
+		{"tree-size-bar", ls.Options{NoIcons: true, SizeBar: 10, Level: 2}, renderTree(".")},
+		{"tree-dark", ls.Options{Theme: ls.Dark}, renderTree(".")},
+		{"glob", ls.Options{Width: 80, NoIcons: true}, renderGlob("**/*.go")},
+		{"glob-links", ls.Options{Width: 80, NoIcons: true}, renderGlob("*")},
+		{"glob-tree", plain, renderTreeGlob("**/*.{go,mkv,flac}")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := test.opts
+			opts.FS = goldenFS()
+			opts.Now = clock(time.UTC)
+			if opts.Theme == nil {
+				opts.NoColors = true
+			}
+
+			var buf bytes.Buffer
+			if err := test.render(&buf, opts); err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", test.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run go test -update to create it)", err)
+			}
+			if got := buf.String(); got != string(want) {
+				t.Errorf("output differs from %s:\n%s\nwant:\n%s", golden, got, want)
+			}
+		})
+	}
+}
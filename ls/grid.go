@@ -0,0 +1,150 @@
+package ls
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Grid renders files in as many columns as fit into Options.Width.
+type Grid struct {
+	opts Options
+}
+
+// NewGrid returns a grid Renderer.
+func NewGrid(opts Options) *Grid {
+	return &Grid{opts}
+}
+
+// Render implements Renderer.
+func (g *Grid) Render(w io.Writer, files []File) error {
+	return g.RenderDir(w, "", files)
+}
+
+// RenderDir implements DirRenderer.
+func (g *Grid) RenderDir(w io.Writer, dir string, files []File) error {
+	opts := g.opts
+
+	sep := opts.Separator
+	if sep == "" {
+		sep = strings.Repeat(" ", opts.colSep())
+	}
+
+	// A name too wide for a line of its own is truncated to fit.
+	maxWidth := opts.Width - 1
+
+	lengths := make([]int, len(files))
+	for i, file := range files {
+		name, target := file.prettyParts(opts, maxWidth)
+		lengths[i] = textWidth(name + target)
+	}
+
+	columns := g.layout(lengths, textWidth(sep))
+	rows := (len(files) + columns - 1) / columns
+	widths := make([]int, columns)
+	for i, length := range lengths {
+		if col := g.column(i, rows, columns); length > widths[col] {
+			widths[col] = length
+		}
+	}
+
+	for row := 0; row < rows; row++ {
+		var line strings.Builder
+		for col := 0; col < columns; col++ {
+			i := g.index(row, col, rows, columns)
+			if i >= len(files) {
+				break
+			}
+
+			if col > 0 {
+				line.WriteString(strings.Repeat(" ", widths[col-1]-lengths[g.index(row, col-1, rows, columns)]))
+				line.WriteString(sep)
+			}
+			line.WriteString(opts.Theme.entry(opts, files[i], maxWidth))
+		}
+
+		if _, err := fmt.Fprintln(w, line.String()); err != nil {
+			return err
+		}
+	}
+
+	if opts.Summary {
+		return Summarize(opts, files).render(w, opts, dir)
+	}
+	return nil
+}
+
+// layout returns the most columns, up to Options.Columns, that names of
+// lengths fit into within Options.Width. Like GNU ls it tries every column
+// count in a single pass over the names, widening the columns of each
+// candidate as it goes.
+func (g *Grid) layout(lengths []int, sepLen int) int {
+	opts := g.opts
+
+	maxColumns := len(lengths)
+	if opts.Width/(1+sepLen)+1 < maxColumns {
+		maxColumns = opts.Width/(1+sepLen) + 1
+	}
+	if opts.Columns > 0 && opts.Columns < maxColumns {
+		maxColumns = opts.Columns
+	}
+	if maxColumns <= 1 || opts.Width <= 0 {
+		return 1
+	}
+
+	type candidate struct {
+		valid   bool
+		lineLen int
+		widths  []int
+	}
+	candidates := make([]candidate, maxColumns)
+	for c := range candidates {
+		candidates[c] = candidate{valid: true, widths: make([]int, c+1)}
+	}
+
+	for i, length := range lengths {
+		for c := range candidates {
+			cand := &candidates[c]
+			if !cand.valid {
+				continue
+			}
+
+			columns := c + 1
+			col := g.column(i, (len(lengths)+columns-1)/columns, columns)
+			if length <= cand.widths[col] {
+				continue
+			}
+
+			if cand.widths[col] == 0 && col > 0 {
+				cand.lineLen += sepLen
+			}
+			cand.lineLen += length - cand.widths[col]
+			cand.widths[col] = length
+			cand.valid = cand.lineLen < opts.Width
+		}
+	}
+
+	for c := len(candidates) - 1; c > 0; c-- {
+		if candidates[c].valid {
+			return c + 1
+		}
+	}
+	return 1
+}
+
+// column returns the column of the i-th file, filling columns first or,
+// with Options.Across, rows first.
+func (g *Grid) column(i, rows, columns int) int {
+	if g.opts.Across {
+		return i % columns
+	}
+	return i / rows
+}
+
+// index returns the index of the file at row and col.
+func (g *Grid) index(row, col, rows, columns int) int {
+	if g.opts.Across {
+		return row*columns + col
+	}
+	return col*rows + row
+}
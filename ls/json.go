@@ -0,0 +1,95 @@
+package ls
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/operatios/lsg/category"
+)
+
+// JSON renders files as a JSON array. With tree set, directories contain
+// their children, descending at most Options.Level levels.
+type JSON struct {
+	opts   Options
+	tree   bool
+	lister *Lister
+}
+
+type jsonFile struct {
+	Name      string     `json:"name"`
+	Path      string     `json:"path"`
+	Category  string     `json:"category"`
+	Size      int64      `json:"size"`
+	SizeText  string     `json:"sizeText"`
+	Allocated int64      `json:"allocated,omitempty"`
+	Mode      string     `json:"mode"`
+	ModTime   time.Time  `json:"modTime"`
+	Target    string     `json:"target,omitempty"`
+	Broken    bool       `json:"broken,omitempty"`
+	Children  []jsonFile `json:"children,omitempty"`
+}
+
+type jsonBlock struct {
+	Dir   string     `json:"dir,omitempty"`
+	Files []jsonFile `json:"files"`
+}
+
+// NewJSON returns a JSON Renderer.
+func NewJSON(opts Options, tree bool) *JSON {
+	return &JSON{opts, tree, NewLister(opts)}
+}
+
+// Render implements Renderer.
+func (j *JSON) Render(w io.Writer, files []File) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(j.convert(files, 0))
+}
+
+// RenderBlocks writes blocks to w as one JSON array of objects holding the
+// directory and its files.
+func (j *JSON) RenderBlocks(w io.Writer, blocks []Block) error {
+	result := make([]jsonBlock, 0, len(blocks))
+	for _, block := range blocks {
+		result = append(result, jsonBlock{block.Dir, j.convert(block.Files, 0)})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func (j *JSON) convert(files []File, depth int) []jsonFile {
+	result := make([]jsonFile, 0, len(files))
+
+	for _, file := range files {
+		entry := jsonFile{
+			Name:     file.Name(),
+			Path:     file.path,
+			Category: category.Names[file.Category()],
+			Size:     file.Size(),
+			SizeText: formatSize(j.opts, file.displaySize(j.opts)),
+			Mode:     file.fileMode(),
+			ModTime:  j.opts.modTime(file),
+		}
+
+		if j.opts.Blocks {
+			entry.Allocated = file.allocated()
+		}
+
+		if file.IsLink() {
+			entry.Target = file.Target()
+			entry.Broken = file.IsBroken()
+		}
+
+		if j.tree && file.IsDir() && !file.IsLink() &&
+			(j.opts.Level == 0 || depth+1 < j.opts.Level) {
+			subFiles, _ := j.lister.list(file.path, file.root)
+			entry.Children = j.convert(subFiles, depth+1)
+		}
+
+		result = append(result, entry)
+	}
+	return result
+}
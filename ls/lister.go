@@ -0,0 +1,264 @@
+package ls
+
+import (
+	"io/fs"
+	"sort"
+)
+
+// Lister reads directories, filters out hidden files and sorts the result.
+type Lister struct {
+	opts Options
+}
+
+// Block is a directory and the files listed in it.
+type Block struct {
+	Dir   string
+	Files []File
+}
+
+// NewLister returns a Lister using the listing part of opts.
+func NewLister(opts Options) *Lister {
+	return &Lister{opts}
+}
+
+// List returns the sorted contents of the directory at path.
+func (l *Lister) List(path string) ([]File, error) {
+	return l.list(path, "")
+}
+
+// list is List with link targets shown relative to root.
+func (l *Lister) list(path, root string) ([]File, error) {
+	files, err := getFiles(l.opts.fs(), path, root, l.opts.All)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Sort(files)
+	return files, nil
+}
+
+// Files returns the sorted files at paths, skipping the ones that vanished.
+func (l *Lister) Files(paths []string) []File {
+	files := getParentFiles(l.opts.fs(), paths, l.opts.All)
+	l.Sort(files)
+	return files
+}
+
+// Sort sorts files in place.
+func (l *Lister) Sort(files []File) {
+	sortFiles(files, l.opts)
+}
+
+// Operands sorts paths named on a command line into files, which are listed
+// together, and directories, which are listed one by one. Links to
+// directories count as directories. With Options.Directory every path is a
+// file. Hidden files are never filtered out here.
+func (l *Lister) Operands(paths []string) (files []File, dirs []string, errs []error) {
+	fsys := l.opts.fs()
+
+	for _, path := range paths {
+		file, err := NewFile(fsys, path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !l.opts.Directory && file.isDirTarget() {
+			dirs = append(dirs, path)
+			continue
+		}
+
+		file.name = path
+		files = append(files, file)
+	}
+
+	l.Sort(files)
+	return files, dirs, errs
+}
+
+// IsGlob reports whether path is a pattern to glob rather than a file: it has
+// glob syntax and no file of that name exists, so that names like "[x]" can
+// still be listed.
+func (l *Lister) IsGlob(path string) bool {
+	if !IsPattern(path) {
+		return false
+	}
+	_, err := l.opts.fs().Lstat(path)
+	return err != nil
+}
+
+// Glob returns the files matching pattern grouped by their parent directory,
+// leaving out the ones matching Options.Exclude. It fails with ErrNoMatch if
+// no file is left.
+func (l *Lister) Glob(pattern string) ([]Block, error) {
+	fileNames, err := l.matches(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	p := pathsOf(l.opts.fs())
+	parents := make(map[string][]string)
+	for _, fileName := range fileNames {
+		dir := p.dir(fileName)
+		parents[dir] = append(parents[dir], fileName)
+	}
+
+	keys := make([]string, 0, len(parents))
+	for k := range parents {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return cmpCaseInsensitive(keys[i], keys[j])
+	})
+
+	var blocks []Block
+	for _, parent := range keys {
+		if !l.opts.All && isPathHidden(l.opts.fs(), parent) {
+			continue
+		}
+
+		children := l.Files(parents[parent])
+		if len(children) == 0 {
+			continue
+		}
+
+		blocks = append(blocks, Block{parent, children})
+	}
+	if len(blocks) == 0 {
+		return nil, noMatch(pattern)
+	}
+	return blocks, nil
+}
+
+// GlobFiles returns the files matching pattern as one sorted list, named by
+// their path and leaving out the ones matching Options.Exclude. It fails with
+// ErrNoMatch if no file is left.
+func (l *Lister) GlobFiles(pattern string) ([]File, error) {
+	fileNames, err := l.matches(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	fsys := l.opts.fs()
+	hiddenDirs := make(map[string]bool)
+
+	var files []File
+	for _, fileName := range fileNames {
+		if !l.opts.All {
+			dir := pathsOf(fsys).dir(fileName)
+			hidden, ok := hiddenDirs[dir]
+			if !ok {
+				hidden = isPathHidden(fsys, dir)
+				hiddenDirs[dir] = hidden
+			}
+			if hidden {
+				continue
+			}
+		}
+
+		file, err := NewFile(fsys, fileName)
+		if err != nil || !l.opts.All && file.IsHidden() {
+			continue
+		}
+
+		file.name = fileName
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, noMatch(pattern)
+	}
+
+	l.Sort(files)
+	return files, nil
+}
+
+// matches returns the names matching pattern but none of Options.Exclude.
+func (l *Lister) matches(pattern string) ([]string, error) {
+	fileNames, err := glob(l.opts.fs(), pattern, l.opts.GlobIgnoreCase)
+	if err != nil {
+		return nil, err
+	}
+
+	result := fileNames[:0]
+	for _, fileName := range fileNames {
+		if !excluded(l.opts.fs(), l.opts.Exclude, fileName, l.opts.GlobIgnoreCase) {
+			result = append(result, fileName)
+		}
+	}
+	return result, nil
+}
+
+// WalkFunc is called by Walk for every directory. If reading dir failed,
+// files is nil and err is the reason. A non-nil return value stops the walk.
+type WalkFunc func(dir string, files []File, err error) error
+
+// Walk calls fn for root and then every directory below it in depth-first
+// order, descending at most Options.Level levels. Links are not followed.
+func (l *Lister) Walk(root string, fn WalkFunc) error {
+	return l.walk(root, 1, fn)
+}
+
+func (l *Lister) walk(dir string, depth int, fn WalkFunc) error {
+	files, err := l.List(dir)
+	if err := fn(dir, files, err); err != nil {
+		return err
+	}
+
+	if l.opts.Level > 0 && depth >= l.opts.Level {
+		return nil
+	}
+
+	for _, file := range files {
+		if file.IsDir() && !file.IsLink() {
+			if err := l.walk(file.path, depth+1, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getFiles reads the directory at path. Link targets of the returned files
+// are shown relative to root, or to the working directory if root is empty.
+func getFiles(fsys FS, path, root string, showHidden bool) ([]File, error) {
+	var result []File
+
+	entries, err := fs.ReadDir(fsys, path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		fileInfo, err := entry.Info()
+
+		// File got deleted while executing
+		if err != nil {
+			continue
+		}
+
+		file := File{fsys: fsys, info: fileInfo, path: pathsOf(fsys).join(path, fileInfo.Name()), root: root}
+
+		if showHidden || !file.IsHidden() {
+			result = append(result, file)
+		}
+	}
+	return result, nil
+}
+
+func getParentFiles(fsys FS, fileNames []string, showHidden bool) []File {
+	var result []File
+
+	for _, fileName := range fileNames {
+		file, err := NewFile(fsys, fileName)
+
+		if err != nil {
+			continue
+		}
+
+		if showHidden || !file.IsHidden() {
+			result = append(result, file)
+		}
+	}
+	return result
+}
@@ -0,0 +1,202 @@
+package ls_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/operatios/lsg/ls"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		opts ls.Options
+		want []string
+	}{
+		{ls.Options{}, []string{"a.txt", "B.go", "broken", "dir", "empty", "file10", "file2", "link"}},
+		{ls.Options{All: true}, []string{".hidden", "a.txt", "B.go", "broken", "dir", "empty", "file10", "file2", "link"}},
+		{ls.Options{Sort: "version"}, []string{"B.go", "a.txt", "broken", "dir", "empty", "file2", "file10", "link"}},
+		{ls.Options{Sort: "-size"}, []string{"dir", "empty", "a.txt", "link", "broken", "file10", "file2", "B.go"}},
+		{ls.Options{Sort: "time"}, []string{"broken", "dir", "empty", "file2", "link", "a.txt", "B.go", "file10"}},
+		{ls.Options{Sort: "extension"}, []string{"broken", "dir", "empty", "file10", "file2", "link", "B.go", "a.txt"}},
+		{ls.Options{Reverse: true}, []string{"link", "file2", "file10", "empty", "dir", "broken", "B.go", "a.txt"}},
+		{ls.Options{GroupDirs: "first", Reverse: true}, []string{"empty", "dir", "link", "file2", "file10", "broken", "B.go", "a.txt"}},
+		{ls.Options{GroupDirs: "last"}, []string{"a.txt", "B.go", "broken", "file10", "file2", "link", "dir", "empty"}},
+	}
+
+	for _, test := range tests {
+		test.opts.FS = testFS()
+		files, err := ls.NewLister(test.opts).List(".")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(files); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("List with %+v = %q, want %q", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestListMissing(t *testing.T) {
+	if _, err := ls.NewLister(ls.Options{FS: testFS()}).List("missing"); err == nil {
+		t.Error("List of a missing directory succeeded")
+	}
+}
+
+func TestFiles(t *testing.T) {
+	files := ls.NewLister(ls.Options{FS: testFS()}).Files([]string{"file2", "missing", ".hidden", "dir/x.md"})
+	if got, want := names(files), []string{"file2", "x.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Files = %q, want %q", got, want)
+	}
+	if got, want := files[1].Path(), "dir/x.md"; got != want {
+		t.Errorf("Path = %q, want %q", got, want)
+	}
+}
+
+func TestSort(t *testing.T) {
+	lister := ls.NewLister(ls.Options{FS: testFS(), Sort: "size,name"})
+	files := lister.Files([]string{"file2", "a.txt", "B.go", "link"})
+
+	lister.Sort(files)
+	if got, want := names(files), []string{"B.go", "file2", "a.txt", "link"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sort = %q, want %q", got, want)
+	}
+}
+
+func TestOperands(t *testing.T) {
+	lister := ls.NewLister(ls.Options{FS: testFS()})
+	files, dirs, errs := lister.Operands([]string{"dir", "link", "missing", "a.txt"})
+
+	if got, want := names(files), []string{"a.txt", "link"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("files = %q, want %q", got, want)
+	}
+	if want := []string{"dir"}; !reflect.DeepEqual(dirs, want) {
+		t.Errorf("dirs = %q, want %q", dirs, want)
+	}
+	if len(errs) != 1 {
+		t.Errorf("errs = %v, want one error", errs)
+	}
+
+	lister = ls.NewLister(ls.Options{FS: testFS(), Directory: true})
+	if files, dirs, _ := lister.Operands([]string{"dir", "a.txt"}); len(files) != 2 || len(dirs) != 0 {
+		t.Errorf("with Directory: files = %q, dirs = %q, want only files", names(files), dirs)
+	}
+}
+
+func TestGlob(t *testing.T) {
+	tests := []struct {
+		opts    ls.Options
+		pattern string
+		want    map[string][]string
+	}{
+		{ls.Options{}, "**/*.txt", map[string][]string{".": {"a.txt"}, "dir/sub": {"deep.txt"}}},
+		{ls.Options{All: true}, "**/*.txt", map[string][]string{".": {"a.txt"}, "dir/.secret": {"k.txt"}, "dir/sub": {"deep.txt"}}},
+		{ls.Options{Exclude: []string{"dir/**"}}, "**/*.txt", map[string][]string{".": {"a.txt"}}},
+		{ls.Options{GlobIgnoreCase: true}, "*.GO", map[string][]string{".": {"B.go"}}},
+		{ls.Options{}, "file*", map[string][]string{".": {"file10", "file2"}}},
+		{ls.Options{}, "file?", map[string][]string{".": {"file2"}}},
+		{ls.Options{}, "[!f]*.{go,txt}", map[string][]string{".": {"a.txt", "B.go"}}},
+	}
+
+	for _, test := range tests {
+		test.opts.FS = testFS()
+		blocks, err := ls.NewLister(test.opts).Glob(test.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := make(map[string][]string)
+		for _, block := range blocks {
+			got[block.Dir] = names(block.Files)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Glob(%q) with %+v = %q, want %q", test.pattern, test.opts, got, test.want)
+		}
+	}
+}
+
+func TestGlobNoMatch(t *testing.T) {
+	lister := ls.NewLister(ls.Options{FS: testFS(), Exclude: []string{"*.go"}})
+	for _, pattern := range []string{"*.nope", "*.go", ".hid*"} {
+		if _, err := lister.Glob(pattern); !errors.Is(err, ls.ErrNoMatch) {
+			t.Errorf("Glob(%q) error = %v, want %v", pattern, err, ls.ErrNoMatch)
+		}
+		if _, err := lister.GlobFiles(pattern); !errors.Is(err, ls.ErrNoMatch) {
+			t.Errorf("GlobFiles(%q) error = %v, want %v", pattern, err, ls.ErrNoMatch)
+		}
+	}
+}
+
+func TestIsGlob(t *testing.T) {
+	lister := ls.NewLister(ls.Options{FS: memFS{MapFS: fstest.MapFS{
+		"[x]":      file(0, 0o644, 0),
+		"notes{1}": file(0, 0o644, 0),
+	}}})
+	tests := map[string]bool{"[x]": false, "notes{1}": false, "a.txt": false, "[y]": true, "*.txt": true}
+
+	for path, want := range tests {
+		if got := lister.IsGlob(path); got != want {
+			t.Errorf("IsGlob(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestGlobFiles(t *testing.T) {
+	files, err := ls.NewLister(ls.Options{FS: testFS()}).GlobFiles("**/*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := names(files), []string{"a.txt", "dir/sub/deep.txt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GlobFiles = %q, want %q", got, want)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		opts ls.Options
+		want []string
+	}{
+		{ls.Options{}, []string{".", "dir", "dir/sub", "empty"}},
+		{ls.Options{All: true}, []string{".", "dir", "dir/.secret", "dir/sub", "empty"}},
+		{ls.Options{Level: 1}, []string{"."}},
+		{ls.Options{Level: 2}, []string{".", "dir", "empty"}},
+	}
+
+	for _, test := range tests {
+		test.opts.FS = testFS()
+		var got []string
+		err := ls.NewLister(test.opts).Walk(".", func(dir string, files []ls.File, err error) error {
+			got = append(got, dir)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Walk with %+v visited %q, want %q", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestWalkStops(t *testing.T) {
+	stop := errors.New("stop")
+
+	var visited int
+	err := ls.NewLister(ls.Options{FS: testFS()}).Walk(".", func(dir string, files []ls.File, err error) error {
+		visited++
+		if dir == "dir" {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || visited != 2 {
+		t.Errorf("Walk returned %v after %d directories, want %v after 2", err, visited, stop)
+	}
+}
+
+func TestWrapFS(t *testing.T) {
+	opts := ls.Options{FS: ls.WrapFS(fstest.MapFS{"b/c": {}, "a": {}}), Width: 40, NoIcons: true}
+	if got := render(t, opts, ls.NewGrid(opts), "."); got != "a  b\n" {
+		t.Errorf("Grid over a wrapped MapFS = %q, want %q", got, "a  b\n")
+	}
+}
@@ -0,0 +1,276 @@
+package ls
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+)
+
+// Long renders files one per line with their size, modification time and,
+// with Options.Extend, their mode, link count and owner.
+type Long struct {
+	opts Options
+}
+
+// NewLong returns a long listing Renderer.
+func NewLong(opts Options) *Long {
+	return &Long{opts}
+}
+
+// Render implements Renderer.
+func (l *Long) Render(w io.Writer, files []File) error {
+	return l.RenderDir(w, "", files)
+}
+
+// RenderDir implements DirRenderer.
+func (l *Long) RenderDir(w io.Writer, dir string, files []File) error {
+	opts := l.opts
+	theme := opts.Theme
+
+	sizes := []string{}
+	var totalSize int64
+
+	var align struct {
+		octal    int
+		access   int
+		size     int
+		unit     int
+		fileMode int
+		nLink    int
+		owner    int
+		group    int
+		attrs    int
+	}
+
+	if opts.Entries {
+		countEntries(files, opts.CountHidden)
+	}
+
+	fileSizes := make([]int64, len(files))
+	for i, file := range files {
+		fileSizes[i] = file.displaySize(opts)
+	}
+	scale := newSizeScale(opts, fileSizes)
+
+	attrs := make([]string, len(files))
+	immutable := make([]bool, len(files))
+
+	// Units are padded to the longest one, so that numbers line up.
+	units := make([]int, len(files))
+	for i, file := range files {
+		var sizeEntry string
+		totalSize += fileSizes[i]
+
+		if major, minor, ok := file.device(); ok {
+			sizeEntry = fmt.Sprintf("%d, %d", major, minor)
+		} else if opts.Entries && file.counted && file.entries < 0 {
+			sizeEntry = "?"
+		} else {
+			sizeEntry = formatSize(opts, fileSizes[i])
+			if opts.Entries && file.counted {
+				sizeEntry = plural(file.entries, "item", "items")
+			}
+			units[i] = unitLen(sizeEntry)
+			if units[i] > align.unit {
+				align.unit = units[i]
+			}
+		}
+		sizes = append(sizes, sizeEntry)
+
+		if opts.Extend {
+			modeLen := len(file.fileMode())
+			if modeLen > align.fileMode {
+				align.fileMode = modeLen
+			}
+
+			nLinkLen := len(fmt.Sprint(file.nLink()))
+			if nLinkLen > align.nLink {
+				align.nLink = nLinkLen
+			}
+		}
+
+		if opts.Attrs {
+			attrs[i], immutable[i] = file.attributes()
+			if len(attrs[i]) > align.attrs {
+				align.attrs = len(attrs[i])
+			}
+		}
+
+		if opts.Extend && runtime.GOOS != "windows" {
+			ownerLen := len(file.owner())
+			if ownerLen > align.owner {
+				align.owner = ownerLen
+			}
+			groupLen := len(file.group())
+			if groupLen > align.group {
+				align.group = groupLen
+			}
+		}
+	}
+
+	align.octal, align.access = 4, 3
+
+	var labels map[string]string
+	if opts.Header {
+		labels = headerLabels(opts)
+
+		widen := func(width *int, column string) {
+			if l := textWidth(labels[column]); l > *width {
+				*width = l
+			}
+		}
+		widen(&align.octal, "octal")
+		widen(&align.fileMode, "mode")
+		if align.attrs > 0 {
+			widen(&align.attrs, "attrs")
+		}
+		widen(&align.nLink, "nLink")
+		widen(&align.owner, "owner")
+		widen(&align.group, "group")
+		widen(&align.access, "access")
+		widen(&align.size, "size")
+	}
+
+	for i := range sizes {
+		if _, _, ok := files[i].device(); !ok {
+			sizes[i] += strings.Repeat(" ", align.unit-units[i])
+		}
+		if len(sizes[i]) > align.size {
+			align.size = len(sizes[i])
+		}
+	}
+
+	total := theme.total(opts, "  total %s\n", formatSize(opts, totalSize))
+	if _, err := io.WriteString(w, total); err != nil {
+		return err
+	}
+
+	if opts.Header {
+		header := "  "
+		if opts.Octal {
+			header += fmt.Sprintf("%-*s  ", align.octal, labels["octal"])
+		}
+		if opts.Extend {
+			header += fmt.Sprintf("%-*s   ", align.fileMode, labels["mode"])
+		}
+		if align.attrs > 0 {
+			header += fmt.Sprintf("%-*s  ", align.attrs, labels["attrs"])
+		}
+		if opts.Extend {
+			header += fmt.Sprintf("%*s  ", align.nLink, labels["nLink"])
+		}
+		if opts.Extend && runtime.GOOS != "windows" {
+			header += fmt.Sprintf("%-*s  %-*s", align.owner, labels["owner"], align.group, labels["group"])
+		}
+		if opts.Access {
+			if opts.Extend {
+				header += "  "
+			}
+			header += fmt.Sprintf("%-*s", align.access, labels["access"])
+		}
+		header += fmt.Sprintf("%*s", align.size+3, labels["size"])
+		if opts.SizeBar > 0 {
+			header += strings.Repeat(" ", opts.SizeBar+2)
+		}
+		header += fmt.Sprintf("   %-*s  %s", len(timeFormat), labels["time"], labels["name"])
+
+		if _, err := fmt.Fprintln(w, theme.header(opts, header)); err != nil {
+			return err
+		}
+	}
+
+	for i, file := range files {
+		line := "  "
+		if opts.Octal {
+			line += theme.nLink(opts, "%-*s  ", align.octal, file.octalMode())
+		}
+
+		if opts.Extend {
+			line += theme.mode(opts, "%-*s   ", file.fileMode(), align.fileMode)
+		}
+
+		if align.attrs > 0 {
+			line += theme.attrs(opts, "%-*s  ", attrs[i], immutable[i], align.attrs)
+		}
+
+		if opts.Extend {
+			line += theme.nLink(opts, "%*d  ", align.nLink, file.nLink())
+		}
+
+		if opts.Extend && runtime.GOOS != "windows" {
+			owner := file.owner()
+			group := file.group()
+
+			// WSL: file owner of /mnt/ is ""
+			if owner == "" {
+				owner = group
+			}
+
+			line += theme.owner(opts, "%-*s  ", owner, align.owner)
+			line += theme.group(opts, "%-*s", align.group, group)
+		}
+
+		if opts.Access {
+			if opts.Extend {
+				line += "  "
+			}
+			line += theme.mode(opts, "%-*s", file.access(), align.access)
+		}
+
+		level := scale.level(fileSizes[i])
+		line += theme.size(opts, "%*s", sizes[i], level, align.size+3)
+		if opts.SizeBar > 0 {
+			line += theme.size(opts, "  %-*s", scale.bar(fileSizes[i], opts.SizeBar), level, opts.SizeBar)
+		}
+		line += theme.time(opts, file, 3)
+		line += theme.entry(opts, files[i], opts.Width-visibleLen(line)-1)
+
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	if opts.Summary {
+		return Summarize(opts, files).render(w, opts, dir)
+	}
+	return nil
+}
+
+// headerLabels returns the header of every column by name, the one of the
+// first sort key marked with the direction of the listing.
+func headerLabels(opts Options) map[string]string {
+	labels := map[string]string{
+		"octal":  "Octal",
+		"mode":   "Permissions",
+		"attrs":  "Flags",
+		"nLink":  "Links",
+		"owner":  "User",
+		"group":  "Group",
+		"access": "Access",
+		"size":   "Size",
+		"time":   "Modified",
+		"name":   "Name",
+	}
+
+	keys, _ := parseSort(opts.Sort)
+	key := keys[0]
+
+	// Sizes and times go from large to small by default.
+	ascending := key.desc == opts.Reverse
+	if key.name == "size" || key.name == "time" {
+		ascending = !ascending
+	}
+	arrow := "↑"
+	if !ascending {
+		arrow = "↓"
+	}
+
+	switch key.name {
+	case "size", "time", "name":
+		labels[key.name] += " " + arrow
+	default:
+		labels["name"] += fmt.Sprintf(" (%s %s)", key.name, arrow)
+	}
+	return labels
+}
@@ -0,0 +1,146 @@
+package ls
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/operatios/lsg/icons"
+)
+
+// Options control how files are listed and rendered.
+type Options struct {
+	All       bool   // do not ignore hidden files
+	Directory bool   // list directories themselves, not their contents
+	Sort      string // comma separated sort keys, "-" in front of a key reverses it
+	Reverse   bool   // reverse file order
+	GroupDirs string // put directories "first", "last" or "none"
+	Collate   string // compare names by "bytes", the "locale" of the environment or a language tag
+	Level     int    // with trees and recursive listings: maximum depth, 0 for no limit
+	Compact   bool   // with trees: merge chains of single directories into one "a/b/c" entry
+
+	Exclude        []string // glob matches matching any of these patterns are left out
+	GlobIgnoreCase bool     // match glob patterns case-insensitively
+
+	Bytes       bool   // print sizes in bytes
+	SI          bool   // print human readable sizes in powers of 1000, not 1024
+	BlockSize   string // print sizes in units like "K", "MB" or "1K", see parseBlockSize
+	Precision   *int   // digits after the decimal point of human readable sizes; if nil or -1, one below 9 units and none above
+	GroupDigits bool   // separate thousands with commas in sizes printed in bytes or blocks
+	Blocks      bool   // print the space files take up on disk instead of their size
+	Extend      bool   // long format: print file mode and owner/group info
+	Octal       bool   // long format: print permissions in octal
+	Access      bool   // long format: print what the current user may do with a file
+	Attrs       bool   // long format: print inode flags like lsattr (Linux only)
+	Header      bool   // long format: print a row naming the columns, marking the sort key
+	Entries     bool   // long format: print the number of entries of directories instead of their size
+	CountHidden bool   // count hidden entries of directories too
+	SizeBar     int    // long format and trees: width of a bar showing each file's share of the total size, 0 for none
+	SizeColors  string // color sizes by "fixed" thresholds or by their "percentile" or "log" scale position in the listing
+	Columns     int    // grid format: maximum amount of columns, 0 for no limit
+	ColSep      *int   // grid format: column separator length, 2 if nil
+	Separator   string // grid format: drawn between columns instead of ColSep spaces
+	Across      bool   // grid format: fill rows first instead of columns
+	Width       int    // width of the output; grids fall back to one column if 0
+
+	Classify  bool   // append an indicator (one of /*@|=>) to names
+	NoTargets bool   // do not print link targets
+	Truncate  string // shorten names wider than Width in the "middle" or at the "end", or "none"
+	NoColors  bool   // do not print colors, implied by a nil Theme
+	NoIcons   bool   // do not print icons
+	IconSet   string // name of the icon set in icons.Sets, icons.Default if empty
+	Summary   bool   // print file counts, sizes by category and free disk space after a listing
+
+	Theme *Theme
+	FS    FS               // file system to list, OS if nil
+	Now   func() time.Time // clock whose location times are shown in, time.Now if nil
+}
+
+// Validate reports the first option that is out of range.
+func (o Options) Validate() error {
+	if _, err := parseSort(o.Sort); err != nil {
+		return err
+	}
+	if _, err := newCollator(o.Collate); err != nil {
+		return err
+	}
+	if _, ok := icons.Sets[o.IconSet]; !ok && o.IconSet != "" {
+		return fmt.Errorf("invalid icon set: %s", o.IconSet)
+	}
+	if !validTruncate(o.Truncate) {
+		return fmt.Errorf("invalid truncation: %s", o.Truncate)
+	}
+	if !validGroupDirs(o.GroupDirs) {
+		return fmt.Errorf("invalid directory grouping: %s", o.GroupDirs)
+	}
+	if o.BlockSize != "" {
+		if _, _, err := parseBlockSize(o.BlockSize); err != nil {
+			return err
+		}
+	}
+	if o.Precision != nil && *o.Precision < -1 {
+		return errors.New("precision should be >=-1")
+	}
+	if !validSizeColors(o.SizeColors) {
+		return fmt.Errorf("invalid size coloring: %s", o.SizeColors)
+	}
+	if o.SizeBar < 0 {
+		return errors.New("size bar width should be >=0")
+	}
+	if o.ColSep != nil && *o.ColSep < 0 {
+		return errors.New("column separator length should be >=0")
+	}
+	if o.Level < 0 {
+		return errors.New("level should be >=0")
+	}
+	for _, pattern := range o.Exclude {
+		if _, err := normalizePattern(pattern, false, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o Options) fs() FS {
+	if o.FS == nil {
+		return OS
+	}
+	return o.FS
+}
+
+func (o Options) now() time.Time {
+	if o.Now == nil {
+		return time.Now()
+	}
+	return o.Now()
+}
+
+// modTime returns the modification time of f in the location of the clock.
+func (o Options) modTime(f File) time.Time {
+	return f.info.ModTime().In(o.now().Location())
+}
+
+func (o Options) colSep() int {
+	if o.ColSep == nil {
+		return 2
+	}
+	return *o.ColSep
+}
+
+func (o Options) precision() int {
+	if o.Precision == nil {
+		return -1
+	}
+	return *o.Precision
+}
+
+func (o Options) icons() *icons.Set {
+	if set, ok := icons.Sets[o.IconSet]; ok {
+		return set
+	}
+	return icons.Sets[icons.Default]
+}
+
+func (o Options) colors() bool {
+	return !o.NoColors && o.Theme != nil
+}
@@ -0,0 +1,15 @@
+package ls
+
+import "io"
+
+// Renderer writes a listing of files to w.
+type Renderer interface {
+	Render(w io.Writer, files []File) error
+}
+
+// DirRenderer is a Renderer that can be told the directory files were listed
+// from. Options.Summary then includes the free space of its file system.
+type DirRenderer interface {
+	Renderer
+	RenderDir(w io.Writer, dir string, files []File) error
+}
@@ -0,0 +1,390 @@
+package ls_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/operatios/lsg/ls"
+)
+
+// render lists dir with opts and writes it with renderer.
+func render(t *testing.T, opts ls.Options, renderer ls.Renderer, dir string) string {
+	t.Helper()
+
+	files, err := ls.NewLister(opts).List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, files); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestGrid(t *testing.T) {
+	tests := []struct {
+		opts ls.Options
+		want string
+	}{
+		{ls.Options{Width: 40}, "" +
+			"a.txt              empty\n" +
+			"B.go               file10\n" +
+			"broken -> missing  file2\n" +
+			"dir                link -> a.txt\n"},
+		{ls.Options{Width: 40, Across: true, Separator: " | "}, "" +
+			"a.txt             | B.go\n" +
+			"broken -> missing | dir\n" +
+			"empty             | file10\n" +
+			"file2             | link -> a.txt\n"},
+		{ls.Options{Width: 80, Classify: true, NoTargets: true}, "" +
+			"a.txt  B.go  broken@  dir/  empty/  file10  file2  link@\n"},
+		{ls.Options{Width: 80, ColSep: new(int), NoTargets: true}, "" +
+			"a.txtB.gobrokendiremptyfile10file2link\n"},
+		{ls.Options{Width: 80, Columns: 3}, "" +
+			"a.txt              dir     file2\n" +
+			"B.go               empty   link -> a.txt\n" +
+			"broken -> missing  file10\n"},
+		{ls.Options{}, "a.txt\nB.go\nbroken -> missing\ndir\nempty\nfile10\nfile2\nlink -> a.txt\n"},
+	}
+
+	for _, test := range tests {
+		test.opts.FS = testFS()
+		test.opts.NoIcons = true
+		if got := render(t, test.opts, ls.NewGrid(test.opts), "."); got != test.want {
+			t.Errorf("Grid with %+v:\n%s\nwant:\n%s", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestGridWide(t *testing.T) {
+	fsys := memFS{MapFS: fstest.MapFS{
+		"日本語日本語.md": file(0, 0o644, 0),
+		"x":         file(0, 0o644, 0),
+	}}
+	tests := []struct {
+		opts ls.Options
+		want string
+	}{
+		{ls.Options{Width: 19, NoIcons: true}, "x  日本語日本語.md\n"},
+		{ls.Options{Width: 18, NoIcons: true}, "x\n日本語日本語.md\n"},
+		{ls.Options{Width: 25, IconSet: "emoji"}, "📄 x  📝 日本語日本語.md\n"},
+		{ls.Options{Width: 24, IconSet: "emoji"}, "📄 x\n📝 日本語日本語.md\n"},
+		{ls.Options{Width: 10, NoIcons: true, Truncate: "end"}, "x\n日本….md\n"},
+		{ls.Options{Width: 12, NoIcons: true, Truncate: "middle"}, "x\n日本…語.md\n"},
+	}
+
+	for _, test := range tests {
+		test.opts.FS = fsys
+		if got := render(t, test.opts, ls.NewGrid(test.opts), "."); got != test.want {
+			t.Errorf("Grid with %+v:\n%s\nwant:\n%s", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestLong(t *testing.T) {
+	opts := ls.Options{FS: testFS(), NoIcons: true, Bytes: true, Extend: true, Now: clock(time.UTC)}
+	want := "" +
+		"  total 2 B\n" +
+		"  drwxr-xr-x   1       0 B   Fri May 21 12:30:00 2021  sub\n" +
+		"  -rw-r--r--   1       2 B   Fri May 21 12:30:00 2021  x.md\n"
+
+	if got := render(t, opts, ls.NewLong(opts), "dir"); got != want {
+		t.Errorf("Long:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLongClock(t *testing.T) {
+	opts := ls.Options{FS: testFS(), NoIcons: true, Now: clock(time.FixedZone("UTC+9", 9*60*60))}
+	want := "" +
+		"  total 2 B\n" +
+		"     0 B   Fri May 21 21:30:00 2021  sub\n" +
+		"     2 B   Fri May 21 21:30:00 2021  x.md\n"
+
+	if got := render(t, opts, ls.NewLong(opts), "dir"); got != want {
+		t.Errorf("Long in UTC+9:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLongLinks(t *testing.T) {
+	opts := ls.Options{FS: testFS(), NoIcons: true}
+	got := render(t, opts, ls.NewLong(opts), ".")
+
+	for _, want := range []string{"  broken -> missing\n", "  link -> a.txt\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Long does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestLinkTargets(t *testing.T) {
+	opts := ls.Options{FS: memFS{MapFS: fstest.MapFS{
+		"a.txt":    file(1, 0o644, 0),
+		"dir/x.md": file(1, 0o644, 0),
+		"dir/up":   link("../a.txt"),
+		"dir/abs":  link("/dir/x.md"),
+		"dir/out":  link("/a.txt"),
+		"dir/dead": link("../x.md"),
+	}}}
+	files, err := ls.NewLister(opts).List("dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := ls.NewJSON(opts, false).Render(&buf, files); err != nil {
+		t.Fatal(err)
+	}
+
+	type link struct {
+		Name, Target string
+		Broken       bool
+	}
+	var got []link
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	// Absolute targets count from the root of the FS and are shown relative
+	// to the working directory, which is its root too.
+	want := []link{
+		{"abs", "dir/x.md", false},
+		{"dead", "../x.md", true},
+		{"out", "a.txt", false},
+		{"up", "../a.txt", false},
+		{"x.md", "", false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("links = %+v, want %+v", got, want)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, opts := range []ls.Options{{NoIcons: true, Summary: true}, {NoIcons: true, Summary: true, Blocks: true}} {
+		files, err := ls.NewLister(opts).List(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if err := ls.NewLong(opts).RenderDir(&buf, dir, files); err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(buf.String(), "\n")
+
+		// The summary adds up the sizes of the column, like the total line.
+		total := strings.TrimPrefix(lines[0], "  total ")
+		if want := "0 directories, 2 files, 0 links, " + total; strings.TrimSpace(lines[3]) != want {
+			t.Errorf("summary with Blocks %v = %q, want %q", opts.Blocks, lines[3], want)
+		}
+		if !strings.Contains(buf.String(), " free of ") {
+			t.Errorf("summary of %s has no disk space:\n%s", dir, buf.String())
+		}
+	}
+
+	// Without a directory there is no file system to report on.
+	var buf bytes.Buffer
+	opts := ls.Options{Summary: true}
+	if err := ls.NewGrid(opts).Render(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), " free of ") {
+		t.Errorf("summary without a directory reports disk space:\n%s", buf.String())
+	}
+}
+
+func TestTree(t *testing.T) {
+	tests := []struct {
+		opts ls.Options
+		want string
+	}{
+		{ls.Options{}, "" +
+			"dir\n" +
+			"├─ sub\n" +
+			"│  └─ deep.txt\n" +
+			"└─ x.md\n" +
+			"\n" +
+			"1 directory, 2 files\n"},
+		{ls.Options{Level: 1}, "" +
+			"dir\n" +
+			"├─ sub\n" +
+			"└─ x.md\n" +
+			"\n" +
+			"1 directory, 1 file\n"},
+		{ls.Options{All: true, Compact: true}, "" +
+			"dir\n" +
+			"├─ .secret\n" +
+			"│  └─ k.txt\n" +
+			"├─ sub\n" +
+			"│  └─ deep.txt\n" +
+			"└─ x.md\n" +
+			"\n" +
+			"2 directories, 3 files\n"},
+	}
+
+	for _, test := range tests {
+		test.opts.FS = testFS()
+		test.opts.NoIcons = true
+
+		var buf bytes.Buffer
+		if err := ls.NewTree(test.opts).RenderRoot(&buf, "dir"); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != test.want {
+			t.Errorf("Tree with %+v:\n%s\nwant:\n%s", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestTreeCompact(t *testing.T) {
+	opts := ls.Options{FS: memFS{MapFS: fstest.MapFS{
+		"src/main/java/App.java": file(0, 0o644, 0),
+		"src/test/AppTest.java":  file(0, 0o644, 0),
+	}}, NoIcons: true, Compact: true}
+	want := "" +
+		".\n" +
+		"└─ src\n" +
+		"   ├─ main/java\n" +
+		"   │  └─ App.java\n" +
+		"   └─ test\n" +
+		"      └─ AppTest.java\n" +
+		"\n" +
+		"4 directories, 2 files\n"
+
+	var buf bytes.Buffer
+	if err := ls.NewTree(opts).RenderRoot(&buf, "."); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("compact Tree:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTreeSizeBar(t *testing.T) {
+	opts := ls.Options{FS: memFS{MapFS: fstest.MapFS{
+		"big/large": file(1500, 0o644, 0),
+		"big/small": file(1, 0o644, 0),
+		"tiny/one":  file(1, 0o644, 0),
+	}}, NoIcons: true, SizeBar: 4}
+	tree := ls.NewTree(opts)
+
+	var buf bytes.Buffer
+	for _, root := range []string{"big", "tiny"} {
+		if err := tree.RenderRoot(&buf, root); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := "" +
+		"big\n" +
+		"1.5 KiB  ████  ├─ large\n" +
+		"  1 B    ▏     └─ small\n" +
+		"\n" +
+		"0 directories, 2 files\n" +
+		"tiny\n" +
+		"1 B  ████  └─ one\n" +
+		"\n" +
+		"0 directories, 1 file\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Tree with size bars of two roots:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTreeGlob(t *testing.T) {
+	opts := ls.Options{FS: testFS(), NoIcons: true}
+	want := "" +
+		".\n" +
+		"├─ a.txt\n" +
+		"└─ dir\n" +
+		"   └─ sub\n" +
+		"      └─ deep.txt\n" +
+		"\n" +
+		"2 directories, 2 files\n"
+
+	var buf bytes.Buffer
+	if err := ls.NewTree(opts).RenderGlob(&buf, "**/*.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("glob Tree:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type file struct {
+		Name     string
+		Path     string
+		Category string
+		Size     int64
+		Target   string
+		Broken   bool
+		Children []file
+	}
+
+	opts := ls.Options{FS: testFS()}
+	var got []file
+	if err := json.Unmarshal([]byte(render(t, opts, ls.NewJSON(opts, true), ".")), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	byName := make(map[string]file)
+	for _, f := range got {
+		byName[f.Name] = f
+	}
+
+	if f := byName["B.go"]; f.Category != "code" || f.Size != 300 {
+		t.Errorf("B.go = %+v, want category code and size 300", f)
+	}
+	if f := byName["link"]; f.Target != "a.txt" || f.Broken {
+		t.Errorf("link = %+v, want target a.txt", f)
+	}
+	if f := byName["broken"]; f.Target != "missing" || !f.Broken {
+		t.Errorf("broken = %+v, want broken target missing", f)
+	}
+
+	var children []string
+	for _, child := range byName["dir"].Children {
+		children = append(children, child.Path)
+	}
+	if want := []string{"dir/sub", "dir/x.md"}; !reflect.DeepEqual(children, want) {
+		t.Errorf("children of dir = %q, want %q", children, want)
+	}
+}
+
+func TestJSONBlocks(t *testing.T) {
+	opts := ls.Options{FS: testFS()}
+	blocks, err := ls.NewLister(opts).Glob("**/*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := ls.NewJSON(opts, false).RenderBlocks(&buf, blocks); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []struct {
+		Dir   string
+		Files []struct{ Name string }
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Dir != "." || got[1].Dir != "dir/sub" || got[1].Files[0].Name != "deep.txt" {
+		t.Errorf("RenderBlocks = %+v, want blocks . and dir/sub", got)
+	}
+}
@@ -0,0 +1,128 @@
+package ls
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// sizeColorKeys are the keys of Theme.sc from the smallest to the largest
+// sizes.
+var sizeColorKeys = []int{0, 150, 500, 1024}
+
+// sizeScale places sizes within the distribution of one listing for size
+// bars and Options.SizeColors.
+type sizeScale struct {
+	mode   string
+	total  int64
+	sorted []int64
+}
+
+func newSizeScale(opts Options, sizes []int64) *sizeScale {
+	s := &sizeScale{mode: opts.SizeColors, sorted: append([]int64(nil), sizes...)}
+	for _, size := range sizes {
+		s.total += size
+	}
+	sort.Slice(s.sorted, func(i, j int) bool {
+		return s.sorted[i] < s.sorted[j]
+	})
+	return s
+}
+
+func validSizeColors(mode string) bool {
+	switch mode {
+	case "", "fixed", "percentile", "log":
+		return true
+	}
+	return false
+}
+
+// level returns the index in sizeColorKeys size is colored with.
+func (s *sizeScale) level(size int64) int {
+	switch s.mode {
+	case "percentile":
+		return s.percentileLevel(size)
+	case "log":
+		return s.logLevel(size)
+	}
+	return fixedSizeLevel(size)
+}
+
+// fixedSizeLevel splits sizes at 150 MiB, 500 MiB and 1 GiB.
+func fixedSizeLevel(size int64) int {
+	switch {
+	case size >= 1024*MB:
+		return 3
+	case size >= 500*MB:
+		return 2
+	case size >= 150*MB:
+		return 1
+	}
+	return 0
+}
+
+// percentileLevel splits sizes at the median, the 75th and the 90th
+// percentile of the listing.
+func (s *sizeScale) percentileLevel(size int64) int {
+	if len(s.sorted) == 0 {
+		return 0
+	}
+
+	below := sort.Search(len(s.sorted), func(i int) bool {
+		return s.sorted[i] >= size
+	})
+	rank := float64(below) / float64(len(s.sorted))
+
+	switch {
+	case rank >= 0.9:
+		return 3
+	case rank >= 0.75:
+		return 2
+	case rank >= 0.5:
+		return 1
+	}
+	return 0
+}
+
+// logLevel splits the range between the smallest and the largest size of
+// the listing into quarters on a logarithmic scale.
+func (s *sizeScale) logLevel(size int64) int {
+	if len(s.sorted) == 0 {
+		return 0
+	}
+
+	low := math.Log1p(float64(s.sorted[0]))
+	high := math.Log1p(float64(s.sorted[len(s.sorted)-1]))
+	if high <= low {
+		return 0
+	}
+
+	level := int((math.Log1p(float64(size)) - low) / (high - low) * float64(len(sizeColorKeys)))
+	if level >= len(sizeColorKeys) {
+		return len(sizeColorKeys) - 1
+	}
+	if level < 0 {
+		return 0
+	}
+	return level
+}
+
+// bar returns a bar of width cells filled in proportion to the share of size
+// in the total size of the listing.
+func (s *sizeScale) bar(size int64, width int) string {
+	if s.total <= 0 || size <= 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	eighths := int(math.Round(float64(size) / float64(s.total) * float64(width*8)))
+	if eighths == 0 {
+		eighths = 1
+	}
+
+	bar := strings.Repeat("█", eighths/8)
+	if eighths%8 > 0 {
+		bar += string([]rune("▏▎▍▌▋▊▉")[eighths%8-1])
+	}
+	return fmt.Sprintf("%-*s", width, bar)
+}
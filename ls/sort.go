@@ -0,0 +1,200 @@
+package ls
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// sortKey is a property files are compared by. With desc the default
+// direction of the property is reversed.
+type sortKey struct {
+	name string
+	desc bool
+}
+
+var sortAliases = map[string]string{
+	"n": "name",
+	"s": "size",
+	"t": "time",
+	"x": "extension",
+	"c": "category",
+	"v": "version",
+	"e": "entries",
+}
+
+// parseSort parses a comma separated list of sort keys. A key prefixed with
+// "-" is sorted in the opposite direction. Name is always the last key.
+func parseSort(spec string) ([]sortKey, error) {
+	var keys []sortKey
+	hasName := false
+
+	for _, field := range strings.Split(strings.ToLower(spec), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		name := strings.TrimPrefix(field, "-")
+		if alias, ok := sortAliases[name]; ok {
+			name = alias
+		}
+
+		switch name {
+		case "name", "size", "time", "extension", "category", "version", "entries":
+		default:
+			return nil, fmt.Errorf("invalid sorting parameter: %s", field)
+		}
+
+		hasName = hasName || name == "name"
+		keys = append(keys, sortKey{name, strings.HasPrefix(field, "-")})
+	}
+
+	if !hasName {
+		keys = append(keys, sortKey{"name", false})
+	}
+	return keys, nil
+}
+
+func validGroupDirs(groupDirs string) bool {
+	switch groupDirs {
+	case "", "none", "first", "last":
+		return true
+	}
+	return false
+}
+
+// newCollator returns the collator for Options.Collate: nil for byte order,
+// the locale of the environment for "locale" or else a language tag.
+func newCollator(collation string) (*collate.Collator, error) {
+	switch collation {
+	case "", "bytes":
+		return nil, nil
+	case "locale":
+		collation = envLocale()
+	}
+
+	tag, err := language.Parse(collation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid collation: %s", collation)
+	}
+	return collate.New(tag), nil
+}
+
+// envLocale returns the collation locale of the environment as a language
+// tag, e.g. "de-DE" for LC_COLLATE=de_DE.UTF-8.
+func envLocale() string {
+	for _, env := range []string{"LC_ALL", "LC_COLLATE", "LANG"} {
+		locale := os.Getenv(env)
+		if locale == "" {
+			continue
+		}
+
+		if i := strings.IndexAny(locale, ".@"); i >= 0 {
+			locale = locale[:i]
+		}
+		if locale == "C" || locale == "POSIX" {
+			return "und"
+		}
+		return strings.Replace(locale, "_", "-", -1)
+	}
+	return "und"
+}
+
+// sortFiles stably sorts files by the keys in Options.Sort. Directories are
+// put first or last according to Options.GroupDirs, which Options.Reverse
+// does not affect.
+func sortFiles(files []File, opts Options) {
+	keys, _ := parseSort(opts.Sort)
+	collator, _ := newCollator(opts.Collate)
+
+	for _, key := range keys {
+		if key.name == "entries" {
+			countEntries(files, opts.CountHidden)
+		}
+	}
+
+	group := func(f File) int {
+		switch {
+		case opts.GroupDirs == "first" && f.isDirTarget():
+			return -1
+		case opts.GroupDirs == "last" && f.isDirTarget():
+			return 1
+		}
+		return 0
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := files[i], files[j]
+
+		if groupA, groupB := group(a), group(b); groupA != groupB {
+			return groupA < groupB
+		}
+
+		for _, key := range keys {
+			c := compare(key.name, a, b, collator)
+			if key.desc != opts.Reverse {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+
+		c := strings.Compare(a.path, b.path)
+		if opts.Reverse {
+			c = -c
+		}
+		return c < 0
+	})
+}
+
+// compare compares a and b by key in its default direction: names and
+// extensions ascending, larger and newer files and fuller directories first.
+func compare(key string, a, b File, collator *collate.Collator) int {
+	switch key {
+	case "size":
+		return cmpInt64(b.Size(), a.Size())
+	case "time":
+		return cmpInt64(b.info.ModTime().UnixNano(), a.info.ModTime().UnixNano())
+	case "extension":
+		return cmpString(a.ext(), b.ext(), collator)
+	case "category":
+		return cmpInt64(int64(a.Category()), int64(b.Category()))
+	case "version":
+		return cmpVersion(a.Name(), b.Name())
+	case "entries":
+		return cmpInt64(b.entryCount(), a.entryCount())
+	}
+	return cmpString(a.Name(), b.Name(), collator)
+}
+
+// cmpString compares case-insensitively in byte order, or with collator.
+func cmpString(a, b string, collator *collate.Collator) int {
+	if collator != nil {
+		if c := collator.CompareString(a, b); c != 0 {
+			return c
+		}
+	} else if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func cmpInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func cmpCaseInsensitive(a, b string) bool {
+	return strings.ToLower(a) < strings.ToLower(b)
+}
@@ -0,0 +1,133 @@
+package ls
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/operatios/lsg/category"
+)
+
+// Summary counts listed files by kind and category. Sizes are the ones of
+// the size column, allocated sizes with Options.Blocks.
+type Summary struct {
+	Dirs, Files, Links, Broken int
+	Size                       int64
+
+	Categories map[int]*CategoryTotal
+
+	opts Options
+}
+
+// CategoryTotal is the number and size of the files in one category.
+type CategoryTotal struct {
+	Count int
+	Size  int64
+}
+
+// Add counts file.
+func (s *Summary) Add(file File) {
+	switch {
+	case file.IsLink():
+		s.Links++
+		if file.IsBroken() {
+			s.Broken++
+		}
+	case file.IsDir():
+		s.Dirs++
+	default:
+		s.Files++
+	}
+	size := file.displaySize(s.opts)
+	s.Size += size
+
+	if s.Categories == nil {
+		s.Categories = make(map[int]*CategoryTotal)
+	}
+	total, ok := s.Categories[file.Category()]
+	if !ok {
+		total = &CategoryTotal{}
+		s.Categories[file.Category()] = total
+	}
+	total.Count++
+	total.Size += size
+}
+
+// Summarize counts files listed with opts.
+func Summarize(opts Options, files []File) *Summary {
+	s := &Summary{opts: opts}
+	for _, file := range files {
+		s.Add(file)
+	}
+	return s
+}
+
+// render writes the counts, the categories by descending size and the space
+// of the file system holding dir to w. The space is left out if dir is "".
+func (s *Summary) render(w io.Writer, opts Options, dir string) error {
+	theme := opts.Theme
+
+	line := fmt.Sprintf("%s, %s, %s", plural(s.Dirs, "directory", "directories"),
+		plural(s.Files, "file", "files"), plural(s.Links, "link", "links"))
+	if s.Broken > 0 {
+		line += fmt.Sprintf(" (%d broken)", s.Broken)
+	}
+	line += ", " + formatSize(opts, s.Size)
+	if _, err := io.WriteString(w, theme.total(opts, "  %s\n", line)); err != nil {
+		return err
+	}
+
+	categories := make([]int, 0, len(s.Categories))
+	nameLen, countLen, sizeLen := 0, 0, 0
+	for c, total := range s.Categories {
+		categories = append(categories, c)
+		if l := len(category.Names[c]); l > nameLen {
+			nameLen = l
+		}
+		if l := len(fmt.Sprint(total.Count)); l > countLen {
+			countLen = l
+		}
+		if l := len(formatSize(opts, total.Size)); l > sizeLen {
+			sizeLen = l
+		}
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := s.Categories[categories[i]], s.Categories[categories[j]]
+		if a.Size != b.Size {
+			return a.Size > b.Size
+		}
+		return categories[i] < categories[j]
+	})
+
+	for _, c := range categories {
+		total := s.Categories[c]
+		share := 0.0
+		if s.Size > 0 {
+			share = float64(total.Size) / float64(s.Size) * 100
+		}
+
+		line := "  " + theme.category(opts, c, "%-*s", nameLen, category.Names[c])
+		line += theme.total(opts, "  %*d  %*s  %5.1f%%", countLen, total.Count, sizeLen, formatSize(opts, total.Size), share)
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	if dir == "" || opts.fs() != OS {
+		return nil
+	}
+	free, size, err := diskSpace(dir)
+	if err != nil || size == 0 {
+		return nil
+	}
+	_, err = io.WriteString(w, theme.total(opts, "  %s free of %s (%.0f%% used)\n",
+		formatSize(opts, int64(free)), formatSize(opts, int64(size)), float64(size-free)/float64(size)*100))
+	return err
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -0,0 +1,243 @@
+package ls
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gookit/color"
+	"github.com/operatios/lsg/category"
+)
+
+const (
+	MB = 1024 * 1024
+
+	timeFormat = "Mon Jan 02 15:04:05 2006"
+)
+
+var (
+	eeab46  = color.NewRGBStyle(color.HEX("#B8860B"))
+	c388425 = color.NewRGBStyle(color.HEX("#388425"))
+	c0426a8 = color.HEX("#0426a8")
+	link    = color.HEX("#4169E1")
+
+	// Dark is a Theme for terminals with a dark background.
+	Dark = &Theme{
+		oc:  color.HEX("#fffedb"),
+		gc:  color.HEX("#d7d691"),
+		nc:  color.HEX("#ffffff"),
+		tc:  color.HEX("#71ad8a"),
+		lc:  color.HEX("#eb6b34"),
+		ic:  color.NewRGBStyle(color.HEX("#ffffff"), color.HEX("#b73831")).AddOpts(color.OpBold),
+		hc:  color.NewRGBStyle(color.HEX("#fffedb")).AddOpts(color.OpBold, color.OpUnderscore),
+		orc: color.FgLightRed,
+		mc: map[rune]color.RGBColor{
+			'r': color.HEX("#7ed36e"),
+			'w': color.HEX("#d7d691"),
+			'x': color.HEX("#b73831"),
+			'-': color.HEX("#cd8b89"),
+			'd': color.HEX("#4aaef8"),
+			'L': link,
+		},
+		sc: map[int]color.RGBColor{
+			0:    color.HEX("#fffedb"), // others
+			150:  color.HEX("#fffa53"), // >= 150MiB
+			500:  color.HEX("#f4b13e"), // >= 500MiB
+			1024: color.HEX("#CD950C"), // >= 1G
+		},
+		ec: map[int]*color.RGBStyle{
+			category.File:        color.NewRGBStyle(color.HEX("#6ff44a")),
+			category.Dir:         color.NewRGBStyle(color.HEX("#4aaef8")),
+			category.Symlink:     color.NewRGBStyle(color.HEX("#ebb434")),
+			category.Archive:     color.NewRGBStyle(color.HEX("#cd0000")).AddOpts(color.OpUnderscore),
+			category.Executable:  color.NewRGBStyle(color.HEX("#78fa53")),
+			category.Broken:      color.NewRGBStyle(color.HEX("#eb3434")),
+			category.Code:        c388425,
+			category.Image:       eeab46,
+			category.Audio:       eeab46,
+			category.Video:       eeab46,
+			category.Fifo:        color.NewRGBStyle(color.HEX("#d7d691")),
+			category.Socket:      color.NewRGBStyle(color.HEX("#d670d6")),
+			category.BlockDevice: color.NewRGBStyle(color.HEX("#f4d03f")).AddOpts(color.OpBold),
+			category.CharDevice:  color.NewRGBStyle(color.HEX("#f4d03f")),
+			category.Door:        color.NewRGBStyle(color.HEX("#d670d6")).AddOpts(color.OpBold),
+			category.Setuid:      color.NewRGBStyle(color.HEX("#ffffff"), color.HEX("#cd0000")),
+			category.Setgid:      color.NewRGBStyle(color.HEX("#000000"), color.HEX("#f4d03f")),
+			category.Sticky:      color.NewRGBStyle(color.HEX("#ffffff"), color.HEX("#1e5aa8")),
+
+			category.OtherWritable:       color.NewRGBStyle(color.HEX("#1e5aa8"), color.HEX("#7ed36e")),
+			category.StickyOtherWritable: color.NewRGBStyle(color.HEX("#000000"), color.HEX("#7ed36e")),
+		},
+	}
+
+	// Light is a Theme for terminals with a light background.
+	Light = &Theme{
+		oc:  color.HEX("#191970"),
+		gc:  color.HEX("#808000"),
+		nc:  color.HEX("#2c2c2c"),
+		tc:  color.HEX("#4682B4"),
+		lc:  color.HEX("#225db5"),
+		ic:  color.HEXStyle("#ffffff", "#CD2626").AddOpts(color.OpBold),
+		hc:  color.HEXStyle("#191970").AddOpts(color.OpBold, color.OpUnderscore),
+		orc: color.FgRed,
+		mc: map[rune]color.RGBColor{
+			'r': color.HEX("#a56361"),
+			'w': color.HEX("#b73931"),
+			'x': color.HEX("#0326a8"),
+			'-': color.HEX("#2b2c2c"),
+			'd': c0426a8,
+			'L': link,
+		},
+		sc: map[int]color.RGBColor{
+			0:    color.HEX("#efaa45"), // others
+			150:  color.HEX("#a66321"), // >= 150MiB
+			500:  color.HEX("#a22815"), // >= 500MiB
+			1024: color.HEX("#8B008B"), // >= 1G
+		},
+		ec: map[int]*color.RGBStyle{
+			category.File:        color.HEXStyle("#228B22"),
+			category.Dir:         color.NewRGBStyle(c0426a8),
+			category.Symlink:     color.NewRGBStyle(link),
+			category.Archive:     color.HEXStyle("#cd0000").AddOpts(color.OpUnderscore),
+			category.Executable:  color.HEXStyle("#006400"),
+			category.Broken:      color.HEXStyle("#CD2626").AddOpts(color.OpBold),
+			category.Code:        c388425,
+			category.Image:       eeab46,
+			category.Audio:       eeab46,
+			category.Video:       eeab46,
+			category.Fifo:        color.HEXStyle("#808000"),
+			category.Socket:      color.HEXStyle("#8B008B"),
+			category.BlockDevice: color.HEXStyle("#8B6508").AddOpts(color.OpBold),
+			category.CharDevice:  color.HEXStyle("#8B6508"),
+			category.Door:        color.HEXStyle("#8B008B").AddOpts(color.OpBold),
+			category.Setuid:      color.HEXStyle("#ffffff", "#cd0000"),
+			category.Setgid:      color.HEXStyle("#000000", "#f4d03f"),
+			category.Sticky:      color.HEXStyle("#ffffff", "#0426a8"),
+
+			category.OtherWritable:       color.HEXStyle("#0426a8", "#7ed36e"),
+			category.StickyOtherWritable: color.HEXStyle("#000000", "#7ed36e"),
+		},
+	}
+)
+
+// Theme is a color scheme for every part of the output.
+type Theme struct {
+	mc  map[rune]color.RGBColor // mode color
+	oc  color.RGBColor          // owner color
+	gc  color.RGBColor          // group color
+	nc  color.RGBColor          // nLink color
+	sc  map[int]color.RGBColor  // size color
+	tc  color.RGBColor          // time color
+	ec  map[int]*color.RGBStyle // entry color
+	orc color.Color             // owner root color
+	lc  color.RGBColor          // link real color
+	ic  *color.RGBStyle         // immutable attributes color
+	hc  *color.RGBStyle         // long format header color
+
+	depth ColorDepth // colors are downsampled to this depth
+}
+
+func (t *Theme) mode(opts Options, format, mode string, align int) string {
+	mode = fmt.Sprintf(format, align, mode)
+	if !opts.colors() {
+		return mode
+	}
+	buffer := bytes.Buffer{}
+	for _, c := range mode {
+		buffer.WriteString(t.sprint(t.mc[c], string(c)))
+	}
+	return buffer.String()
+}
+
+func (t *Theme) attrs(opts Options, format, attrs string, immutable bool, align int) string {
+	if !opts.colors() {
+		return fmt.Sprintf(format, align, attrs)
+	}
+	if immutable {
+		return t.sprint(t.ic, attrs) + fmt.Sprintf(format, align-len(attrs), "")
+	}
+	return t.sprintf(t.gc, format, align, attrs)
+}
+
+func (t *Theme) nLink(opts Options, format string, v ...interface{}) string {
+	if !opts.colors() {
+		return fmt.Sprintf(format, v...)
+	}
+	return t.sprintf(color.FgDefault, format, v...)
+}
+
+func (t *Theme) owner(opts Options, format, owner string, align int) string {
+	if !opts.colors() {
+		return fmt.Sprintf(format, align, owner)
+	}
+	if owner == "root" {
+		return t.sprintf(t.orc, format, align, owner)
+	}
+	return t.sprintf(t.oc, format, align, owner)
+}
+
+func (t *Theme) group(opts Options, format string, v ...interface{}) string {
+	if !opts.colors() {
+		return fmt.Sprintf(format, v...)
+	}
+	return t.sprintf(t.gc, format, v...)
+}
+
+func (t *Theme) size(opts Options, format, entry string, level int, align int) string {
+	if !opts.colors() {
+		return fmt.Sprintf(format, align, entry)
+	}
+	return t.sprintf(t.sc[sizeColorKeys[level]], format, align, entry)
+}
+
+func (t *Theme) time(opts Options, f File, alignOffset int) string {
+	formatted := opts.modTime(f).Format(timeFormat)
+	if !opts.colors() {
+		return fmt.Sprintf("%*s  ", len(formatted)+alignOffset, formatted)
+	}
+	return t.sprintf(t.tc, "%*s  ", len(formatted)+alignOffset, formatted)
+}
+
+// entry returns the colored name of f, with Options.Truncate shortened to
+// width runes if that is above 0.
+func (t *Theme) entry(opts Options, f File, width int) string {
+	name, target := f.prettyParts(opts, width)
+
+	if !opts.colors() {
+		return name + target
+	}
+
+	if f.IsBroken() {
+		target += " [Dead link]"
+	}
+	if target != "" {
+		return t.sprint(t.ec[f.Category()], name) + t.sprint(t.lc, target)
+	}
+	return t.sprint(t.ec[f.Category()], name)
+}
+
+func (t *Theme) dir(opts Options, name string) string {
+	if !opts.colors() {
+		return name
+	}
+	return t.sprint(t.ec[category.Dir], name)
+}
+
+func (t *Theme) total(opts Options, format string, v ...interface{}) string {
+	if !opts.colors() {
+		return fmt.Sprintf(format, v...)
+	}
+	return t.sprintf(t.ec[category.File], format, v...)
+}
+
+func (t *Theme) category(opts Options, c int, format string, v ...interface{}) string {
+	if !opts.colors() {
+		return fmt.Sprintf(format, v...)
+	}
+	return t.sprintf(t.ec[c], format, v...)
+}
+
+func (t *Theme) header(opts Options, header string) string {
+	if !opts.colors() {
+		return header
+	}
+	return t.sprint(t.hc, header)
+}
@@ -0,0 +1,310 @@
+package ls
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Tree renders files and everything below them as a tree, descending at
+// most Options.Level levels. Links are not followed. With Options.Compact,
+// chains of directories holding a single directory are merged into one
+// entry.
+type Tree struct {
+	opts    Options
+	lister  *Lister
+	summary *Summary // counts rendered files for the report below the tree
+
+	// With Options.SizeBar: the recursive size of every file by path.
+	sizes     map[string]int64
+	scale     *sizeScale
+	sizeAlign int // width of the size column
+	unitAlign int // width of the longest unit, padded to like in Long
+}
+
+// NewTree returns a tree Renderer.
+func NewTree(opts Options) *Tree {
+	return &Tree{opts: opts, lister: NewLister(opts)}
+}
+
+// RenderRoot writes root followed by everything below it to w. Link targets
+// are shown relative to root. A root that is not a directory, or every root
+// with Options.Directory, is written on its own.
+func (t *Tree) RenderRoot(w io.Writer, root string) error {
+	file, err := NewFile(t.opts.fs(), root)
+	if err != nil {
+		return err
+	}
+
+	if t.opts.Directory || !file.isDirTarget() {
+		file.name = root
+		_, err := fmt.Fprintln(w, t.opts.Theme.entry(t.opts, file, 0))
+		return err
+	}
+
+	p := pathsOf(t.opts.fs())
+	absRoot, err := p.abs(root)
+	if err != nil {
+		return err
+	}
+
+	files, err := t.lister.list(root, absRoot)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w, t.opts.Theme.dir(t.opts, p.join(root))); err != nil {
+		return err
+	}
+	return t.renderAll(w, files, root, t.subFiles)
+}
+
+// RenderGlob writes the files matching pattern to w as a tree pruned to the
+// matches and their parent directories. The tree starts at the directory
+// before the first pattern component.
+func (t *Tree) RenderGlob(w io.Writer, pattern string) error {
+	matches, err := t.lister.GlobFiles(pattern)
+	if err != nil {
+		return err
+	}
+
+	p := pathsOf(t.opts.fs())
+	root := patternRoot(p, pattern)
+	children := make(map[string][]File)
+	seen := make(map[string]bool)
+
+	for _, file := range matches {
+		file.name = ""
+		for file.path != root && !seen[file.path] {
+			seen[file.path] = true
+
+			parent := p.dir(file.path)
+			children[parent] = append(children[parent], file)
+
+			if parent == root || parent == file.path {
+				break
+			}
+			if file, err = NewFile(t.opts.fs(), parent); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := fmt.Fprintln(w, t.opts.Theme.dir(t.opts, root)); err != nil {
+		return err
+	}
+	return t.renderAll(w, children[root], root, func(file File, _ int) []File {
+		return children[file.path]
+	})
+}
+
+// patternRoot returns the directory of the leading components of pattern
+// that contain no glob syntax.
+func patternRoot(p paths, pattern string) string {
+	components := splitPath(p, p.join(pattern))
+
+	i := 0
+	for i < len(components)-1 && !IsPattern(components[i]) {
+		i++
+	}
+
+	root := strings.Join(components[:i], p.separator())
+	if root == "" && p.isAbs(pattern) {
+		return p.separator()
+	}
+	if root == "" {
+		return "."
+	}
+	return root
+}
+
+// Render implements Renderer.
+func (t *Tree) Render(w io.Writer, files []File) error {
+	return t.RenderDir(w, "", files)
+}
+
+// RenderDir implements DirRenderer.
+func (t *Tree) RenderDir(w io.Writer, dir string, files []File) error {
+	return t.renderAll(w, files, dir, t.subFiles)
+}
+
+// renderAll renders files followed by the number of directories and files
+// in the tree like tree(1), or with Options.Summary, a summary of every file
+// in the tree and the file system holding dir.
+func (t *Tree) renderAll(w io.Writer, files []File, dir string, children func(file File, depth int) []File) error {
+	if t.opts.SizeBar > 0 {
+		children = t.measure(files, children)
+		defer func() { t.sizes, t.scale, t.sizeAlign, t.unitAlign = nil, nil, 0, 0 }()
+	}
+
+	t.summary = &Summary{opts: t.opts}
+	defer func() { t.summary = nil }()
+
+	if err := t.render(w, files, 0, map[int]bool{0: true}, children); err != nil {
+		return err
+	}
+	if t.opts.Summary {
+		return t.summary.render(w, t.opts, dir)
+	}
+
+	s := t.summary
+	_, err := io.WriteString(w, t.opts.Theme.total(t.opts, "\n%s, %s\n",
+		plural(s.Dirs, "directory", "directories"), plural(s.Files+s.Links, "file", "files")))
+	return err
+}
+
+// measure computes the recursive size of files and everything below them
+// for size bars. It returns children caching its results, so the tree is
+// read only once.
+func (t *Tree) measure(files []File, children func(file File, depth int) []File) func(file File, depth int) []File {
+	cache := make(map[string][]File)
+	cached := func(file File, depth int) []File {
+		subFiles, ok := cache[file.path]
+		if !ok {
+			subFiles = children(file, depth)
+			cache[file.path] = subFiles
+		}
+		return subFiles
+	}
+
+	t.sizes = make(map[string]int64)
+	t.sizeAlign, t.unitAlign = 0, 0
+	var all []int64
+	var numAlign int
+
+	var sum func(files []File, depth int) int64
+	sum = func(files []File, depth int) int64 {
+		var total int64
+		for _, file := range files {
+			size := file.displaySize(t.opts) + sum(cached(file, depth+1), depth+1)
+			t.sizes[file.path] = size
+			all = append(all, size)
+			total += size
+
+			formatted := formatSize(t.opts, size)
+			unit := unitLen(formatted)
+			if l := len(formatted) - unit; l > numAlign {
+				numAlign = l
+			}
+			if unit > t.unitAlign {
+				t.unitAlign = unit
+			}
+		}
+		return total
+	}
+
+	total := sum(files, 0)
+	t.sizeAlign = numAlign + t.unitAlign
+	t.scale = newSizeScale(t.opts, all)
+	t.scale.total = total
+	return cached
+}
+
+// subFiles returns the contents of directories up to Options.Level.
+func (t *Tree) subFiles(file File, depth int) []File {
+	if !file.IsDir() || file.IsLink() || t.opts.Level != 0 && depth >= t.opts.Level {
+		return nil
+	}
+
+	subFiles, _ := t.lister.list(file.path, file.root)
+	return subFiles
+}
+
+func (t *Tree) render(w io.Writer, files []File, depth int, fromDepths map[int]bool,
+	children func(file File, depth int) []File) error {
+	opts := t.opts
+
+	t.lister.Sort(files)
+
+	for i, file := range files {
+		isLast := i == len(files)-1
+
+		if file.IsDir() {
+			if isLast {
+				delete(fromDepths, depth)
+			} else {
+				fromDepths[depth] = true
+			}
+		}
+
+		var prefix string
+		for i := 0; i < depth; i++ {
+			if exists := fromDepths[i]; exists {
+				prefix += "│  "
+			} else {
+				prefix += "   "
+			}
+		}
+		if isLast {
+			prefix += "└─ "
+		} else {
+			prefix += "├─ "
+		}
+
+		// A compacted chain is drawn as its last directory named by the path
+		// from the first, and sized by the first.
+		first := file
+		chain, subFiles := t.chain(file, depth, children)
+		if len(chain) > 1 {
+			file = chain[len(chain)-1]
+			file.name = pathsOf(t.opts.fs()).join(names(chain)...)
+		}
+
+		if t.scale != nil {
+			size := t.sizes[first.path]
+			level := t.scale.level(size)
+			formatted := formatSize(opts, size)
+			formatted += strings.Repeat(" ", t.unitAlign-unitLen(formatted))
+			prefix = opts.Theme.size(opts, "%*s  ", formatted, level, t.sizeAlign) +
+				opts.Theme.size(opts, "%-*s  ", t.scale.bar(size, opts.SizeBar), level, opts.SizeBar) + prefix
+		}
+
+		if _, err := fmt.Fprintln(w, prefix+opts.Theme.entry(opts, file, opts.Width-visibleLen(prefix)-1)); err != nil {
+			return err
+		}
+		for _, f := range chain {
+			t.summary.Add(f)
+		}
+
+		if len(subFiles) > 0 {
+			// Levels merged into the chain still count towards Options.Level.
+			merged := len(chain) - 1
+			subChildren := func(file File, depth int) []File {
+				return children(file, depth+merged)
+			}
+			if err := t.render(w, subFiles, depth+1, fromDepths, subChildren); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// chain returns file and, with Options.Compact, the directories below it
+// that are the only entry of their parent, along with the files below the
+// last of them.
+func (t *Tree) chain(file File, depth int, children func(file File, depth int) []File) ([]File, []File) {
+	chain := []File{file}
+	subFiles := children(file, depth+1)
+
+	for t.opts.Compact && isPlainDir(file) && len(subFiles) == 1 && isPlainDir(subFiles[0]) {
+		file = subFiles[0]
+		chain = append(chain, file)
+		subFiles = children(file, depth+len(chain))
+	}
+	return chain, subFiles
+}
+
+// isPlainDir reports whether file is a directory and not a link to one.
+func isPlainDir(file File) bool {
+	return file.IsDir() && !file.IsLink()
+}
+
+// names returns the names of files.
+func names(files []File) []string {
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.Name()
+	}
+	return names
+}
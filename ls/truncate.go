@@ -0,0 +1,102 @@
+package ls
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	ellipsis = "…"
+
+	// minNameLen is how short truncation makes names at most, so that
+	// something recognizable is left of them.
+	minNameLen = 6
+)
+
+// widths measures text in terminal columns. Characters of ambiguous width
+// are taken as narrow whatever the locale, as most terminals draw them.
+var widths = &runewidth.Condition{}
+
+func validTruncate(truncate string) bool {
+	switch truncate {
+	case "", "none", "middle", "end":
+		return true
+	}
+	return false
+}
+
+func (o Options) truncates() bool {
+	return o.Truncate == "middle" || o.Truncate == "end"
+}
+
+// shorten truncates name by up to over columns and returns it with what is
+// left of over.
+func shorten(name string, over int, mode string) (string, int) {
+	if over <= 0 {
+		return name, over
+	}
+
+	length := textWidth(name)
+	keep := length - over
+	if keep < minNameLen {
+		keep = minNameLen
+	}
+	if keep >= length {
+		return name, over
+	}
+
+	short := truncateName(name, keep, mode)
+	return short, over - (length - textWidth(short))
+}
+
+// truncateName shortens name to at most n columns by replacing its middle
+// or its end with an ellipsis. The extension is kept if there is enough
+// room for it.
+func truncateName(name string, n int, mode string) string {
+	if textWidth(name) <= n {
+		return name
+	}
+	if n <= 1 {
+		return ellipsis
+	}
+
+	ext := filepath.Ext(name)
+	if ext == name || textWidth(ext)+2 > n {
+		ext = ""
+	}
+	stem := strings.TrimSuffix(name, ext)
+	keep := n - 1 - textWidth(ext)
+
+	if mode == "end" {
+		return widths.Truncate(stem, keep, "") + ellipsis + ext
+	}
+
+	head := (keep + 1) / 2
+	tail := keep - head
+	return widths.Truncate(stem, head, "") + ellipsis + lastColumns(stem, tail) + ext
+}
+
+// lastColumns returns the longest end of s that is at most n columns wide.
+func lastColumns(s string, n int) string {
+	runes := []rune(s)
+	i := len(runes)
+	for i > 0 && widths.RuneWidth(runes[i-1]) <= n {
+		n -= widths.RuneWidth(runes[i-1])
+		i--
+	}
+	return string(runes[i:])
+}
+
+// textWidth returns the number of terminal columns s takes up, two for
+// wide characters like CJK and emoji.
+func textWidth(s string) int {
+	return widths.StringWidth(s)
+}
+
+// visibleLen returns the number of columns s takes up without color codes.
+func visibleLen(s string) int {
+	return textWidth(color.ClearCode(s))
+}
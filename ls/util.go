@@ -0,0 +1,168 @@
+package ls
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+func isPathHidden(fsys FS, path string) bool {
+	if path == "." {
+		return false
+	}
+
+	p := pathsOf(fsys)
+	components := splitPath(p, path)
+
+	for i := 1; i <= len(components); i++ {
+		f := p.join(components[:i]...)
+
+		if strings.Contains(f, "..") {
+			abs, _ := p.abs(f)
+			f = abs
+		}
+
+		file, err := NewFile(fsys, f)
+		if err != nil {
+			continue
+		}
+
+		if file.IsHidden() {
+			return true
+		}
+	}
+	return false
+}
+
+func splitPath(p paths, path string) []string {
+	return strings.Split(path, p.separator())
+}
+
+// formatSize formats size in bytes, in units of Options.BlockSize or with
+// human readable units.
+func formatSize(opts Options, size int64) string {
+	switch {
+	case opts.Bytes:
+		return groupDigits(size, opts.GroupDigits) + " B"
+	case opts.BlockSize != "":
+		unit, suffix, _ := parseBlockSize(opts.BlockSize)
+		blocks := size / unit
+		if size%unit != 0 {
+			blocks++
+		}
+		return groupDigits(blocks, opts.GroupDigits) + suffix
+	}
+	return humanizeSize(size, opts.SI, opts.precision())
+}
+
+// unitLen returns the length of the unit after the number in a formatted
+// size, e.g. 3 for "1.3 KiB", or 0 if there is none.
+func unitLen(size string) int {
+	if i := strings.LastIndexByte(size, ' '); i >= 0 {
+		return len(size) - i - 1
+	}
+	return 0
+}
+
+// parseBlockSize parses a block size like "K", "MB", "1K" or "512". Sizes
+// are shown with the unit as suffix unless the block size starts with a
+// number. "K", "KiB", "M", ... are powers of 1024, "KB", "MB", ... powers
+// of 1000.
+func parseBlockSize(blockSize string) (unit int64, suffix string, err error) {
+	invalid := fmt.Errorf("invalid block size: %s", blockSize)
+
+	digits := 0
+	for digits < len(blockSize) && isDigit(blockSize[digits]) {
+		digits++
+	}
+
+	unit = 1
+	if digits > 0 {
+		if unit, err = strconv.ParseInt(blockSize[:digits], 10, 64); err != nil || unit == 0 {
+			return 0, "", invalid
+		}
+	}
+
+	name := blockSize[digits:]
+	if name == "" {
+		return unit, "", nil
+	}
+
+	base := int64(1024)
+	prefix := strings.ToUpper(name[:1])
+	switch strings.ToUpper(name[1:]) {
+	case "", "IB":
+	case "B":
+		base = 1000
+	default:
+		return 0, "", invalid
+	}
+
+	power := strings.Index("KMGTPE", prefix)
+	if power < 0 {
+		return 0, "", invalid
+	}
+	for i := 0; i <= power; i++ {
+		if unit > math.MaxInt64/base {
+			return 0, "", fmt.Errorf("block size too large: %s", blockSize)
+		}
+		unit *= base
+	}
+
+	if digits > 0 {
+		return unit, "", nil
+	}
+	return unit, name, nil
+}
+
+// groupDigits formats n, with group set separating thousands with commas.
+func groupDigits(n int64, group bool) string {
+	s := strconv.FormatInt(n, 10)
+	if !group {
+		return s
+	}
+
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return sign + b.String()
+}
+
+// humanizeSize formats size with binary units or, with si, decimal ones.
+// With a negative precision, sizes below 9 units get one decimal and larger
+// ones none.
+func humanizeSize(size int64, si bool, precision int) string {
+	base, units := 1024.0, []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB"}
+	if si {
+		base, units = 1000.0, []string{"kB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB"}
+	}
+
+	if float64(size) < base {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	fSize := float64(size) / base
+	for i, unit := range units {
+		if fSize < 1000 || i == len(units)-1 {
+			switch {
+			case precision >= 0:
+				return fmt.Sprintf("%.*f %s", precision, fSize, unit)
+			case fSize < 9:
+				return fmt.Sprintf("%.1f %s", fSize, unit)
+			}
+			return fmt.Sprintf("%.0f %s", fSize, unit)
+		}
+		fSize /= base
+	}
+	return ""
+}
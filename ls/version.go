@@ -0,0 +1,163 @@
+package ls
+
+import (
+	"regexp"
+	"strings"
+)
+
+// semver matches a version like "1.2.0" or "v1.2.0" followed by a "-" that
+// starts a pre-release.
+var semver = regexp.MustCompile(`(^|[^0-9A-Za-z.])(v?[0-9]+\.[0-9]+\.[0-9]+)-([A-Za-z])`)
+
+// cmpVersion compares names the way ls -v does: runs of digits compare by
+// their numeric value, bytes compare case-sensitively, "~" sorts before
+// anything else and suffixes like ".tar.gz" are only compared if the rest
+// is equal. Unlike ls -v, the pre-release of a semantic version sorts
+// before its release, so "v1.0.0-rc1" comes before "v1.0.0".
+func cmpVersion(a, b string) int {
+	if c := filevercmp(preRelease(a), preRelease(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// preRelease replaces the "-" after semantic versions in s with "~".
+func preRelease(s string) string {
+	return semver.ReplaceAllString(s, "${1}${2}~${3}")
+}
+
+func filevercmp(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	}
+
+	// Special files and hidden files go first.
+	for _, special := range []string{".", ".."} {
+		if a == special {
+			return -1
+		}
+		if b == special {
+			return 1
+		}
+	}
+	if a[0] == '.' && b[0] != '.' {
+		return -1
+	}
+	if a[0] != '.' && b[0] == '.' {
+		return 1
+	}
+	if a[0] == '.' && b[0] == '.' {
+		a, b = a[1:], b[1:]
+	}
+
+	aPrefix, bPrefix := a[:suffixIndex(a)], b[:suffixIndex(b)]
+	if aPrefix == bPrefix {
+		return verrevcmp(a, b)
+	}
+	return verrevcmp(aPrefix, bPrefix)
+}
+
+// suffixIndex returns where a suffix matching (\.[A-Za-z~][A-Za-z0-9~]*)*$
+// starts in s, or len(s) if there is none.
+func suffixIndex(s string) int {
+	match := -1
+	readAlpha := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case readAlpha:
+			readAlpha = false
+			if !isAlpha(c) && c != '~' {
+				match = -1
+			}
+		case c == '.':
+			readAlpha = true
+			if match < 0 {
+				match = i
+			}
+		case !isAlpha(c) && !isDigit(c) && c != '~':
+			match = -1
+		}
+	}
+
+	if match < 0 {
+		return len(s)
+	}
+	return match
+}
+
+func verrevcmp(a, b string) int {
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isDigit(a[i]) || j < len(b) && !isDigit(b[j]) {
+			ac, bc := 0, 0
+			if i < len(a) {
+				ac = order(a[i])
+			}
+			if j < len(b) {
+				bc = order(b[j])
+			}
+			if ac != bc {
+				return ac - bc
+			}
+			i++
+			j++
+		}
+
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return firstDiff
+		}
+	}
+	return 0
+}
+
+// order ranks a character outside a number: "~" first, then the end of
+// the string, letters and finally everything else.
+func order(c byte) int {
+	switch {
+	case isDigit(c):
+		return 0
+	case isAlpha(c):
+		return int(c)
+	case c == '~':
+		return -1
+	}
+	return int(c) + 256
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isAlpha(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
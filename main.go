@@ -3,13 +3,13 @@ package main
 import (
 	"bufio"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"runtime"
-	"strings"
+	"strconv"
 
-	"github.com/operatios/lsg/category"
+	"github.com/gookit/color"
+	"github.com/operatios/lsg/ls"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
@@ -17,7 +17,8 @@ var (
 	bufStdout           = bufio.NewWriter(os.Stdout)
 	terminalWidth, _, _ = terminal.GetSize(int(os.Stdout.Fd()))
 
-	theme *Theme
+	// exitStatus becomes 1 once something could not be listed.
+	exitStatus int
 )
 
 func isatty() bool {
@@ -25,25 +26,26 @@ func isatty() bool {
 }
 
 func main() {
-	defer bufStdout.Flush()
-
 	args := getArgs()
 
-	if len(args.paths) == 0 {
-		args.paths = append(args.paths, ".")
+	tty := isatty()
+	args.NoColors = args.NoColors || !enabled(args.color, colorsByEnv(tty))
+	args.NoIcons = args.NoIcons || !enabled(args.icons, tty)
+	if args.Width == 0 {
+		args.Width, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+	}
+	if args.Width <= 0 && tty {
+		args.Width = terminalWidth
 	}
 
-	if !isatty() {
-		args.columns = 1
-		args.noColors = true
-		args.noIcons = true
+	// Only a console interprets escape sequences; pipes take them as they are.
+	if runtime.GOOS == "windows" && !args.NoColors && tty {
+		args.NoColors = enableColors() != nil
 	}
 
-	if runtime.GOOS == "windows" && !args.noColors {
-		err := enableColors()
-		if err != nil {
-			log.Fatal(err)
-		}
+	// From now on the flags decide, not the guess of the color package.
+	if !args.NoColors {
+		color.ForceOpenColor()
 	}
 
 	if args.tree {
@@ -51,52 +53,205 @@ func main() {
 	} else {
 		doLS(args)
 	}
+
+	_ = bufStdout.Flush()
+	os.Exit(exitStatus)
+}
+
+// warn reports err and makes lsg exit with a non-zero status.
+func warn(err error) {
+	_, _ = fmt.Fprintln(os.Stderr, err)
+	exitStatus = 1
+}
+
+// enabled decides an auto, always or never flag, where auto is def.
+func enabled(when string, def bool) bool {
+	switch when {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	return def
+}
+
+// colorsByEnv reports whether to use colors by default: not with NO_COLOR
+// set, always with CLICOLOR_FORCE set and else only on terminals that are
+// not dumb.
+func colorsByEnv(tty bool) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	return tty && os.Getenv("TERM") != "dumb"
+}
+
+// output writes blocks of files, each under a "dir:" header if headers is
+// set. Headers are set for several paths, recursive listings and glob
+// matches, which are grouped by directory. JSON output is collected and
+// written as one document by flush.
+type output struct {
+	renderer ls.Renderer
+	json     *ls.JSON
+	headers  bool
+	blocks   []ls.Block
+	written  int
+}
+
+func newOutput(args Args, lister *ls.Lister) *output {
+	out := &output{headers: len(args.paths) > 1 || args.recursive}
+	for _, path := range args.paths {
+		if lister.IsGlob(path) && !args.flat {
+			out.headers = true
+		}
+	}
+
+	switch {
+	case args.json:
+		out.json = ls.NewJSON(args.Options, args.tree)
+		out.renderer = out.json
+	case args.longList:
+		out.renderer = ls.NewLong(args.Options)
+	default:
+		out.renderer = ls.NewGrid(args.Options)
+	}
+	return out
+}
+
+func (o *output) block(dir string, files []ls.File) {
+	if o.json != nil {
+		o.blocks = append(o.blocks, ls.Block{Dir: dir, Files: files})
+		return
+	}
+
+	if o.headers && dir != "" {
+		if o.written > 0 {
+			_, _ = fmt.Fprintln(bufStdout)
+		}
+		_, _ = fmt.Fprintln(bufStdout, filepath.Clean(dir)+":")
+	}
+	o.written++
+
+	var err error
+	if r, ok := o.renderer.(ls.DirRenderer); ok {
+		err = r.RenderDir(bufStdout, dir, files)
+	} else {
+		err = o.renderer.Render(bufStdout, files)
+	}
+	if err != nil {
+		warn(err)
+	}
+}
+
+func (o *output) flush() {
+	if o.json == nil {
+		return
+	}
+
+	var err error
+	if len(o.blocks) == 1 && !o.headers {
+		err = o.json.Render(bufStdout, o.blocks[0].Files)
+	} else {
+		err = o.json.RenderBlocks(bufStdout, o.blocks)
+	}
+	if err != nil {
+		warn(err)
+	}
 }
 
 func doLS(args Args) {
+	lister := ls.NewLister(args.Options)
+	out := newOutput(args, lister)
+	defer out.flush()
+
+	var operands []string
 	for _, path := range args.paths {
-		if strings.ContainsRune(path, '*') {
-			processGlob(path, args)
-		} else {
-			files, err := getFiles(path, args.all)
+		if !lister.IsGlob(path) {
+			operands = append(operands, path)
+		}
+	}
 
+	files, dirs, errs := lister.Operands(operands)
+	for _, err := range errs {
+		warn(err)
+	}
+	if len(files) > 0 {
+		out.block("", files)
+	}
+
+	var flat []ls.File
+	for _, path := range args.paths {
+		if lister.IsGlob(path) && args.flat {
+			files, err := lister.GlobFiles(path)
 			if err != nil {
-				_, _ = fmt.Fprintln(os.Stderr, err)
+				warn(err)
+				continue
+			}
+			flat = append(flat, files...)
+		} else if lister.IsGlob(path) {
+			blocks, err := lister.Glob(path)
+			if err != nil {
+				warn(err)
 				continue
 			}
 
-			if len(args.paths) > 1 {
-				_, _ = fmt.Fprintln(bufStdout, filepath.Clean(path)+":")
+			for _, block := range blocks {
+				out.block(block.Dir, block.Files)
+			}
+		} else if len(dirs) > 0 && dirs[0] == path {
+			dirs = dirs[1:]
+			if args.recursive {
+				doRecursive(lister, out, path)
+				continue
 			}
 
-			processFiles(files, args)
+			files, err := lister.List(path)
+			if err != nil {
+				warn(err)
+				continue
+			}
+			out.block(path, files)
 		}
 	}
+
+	if len(flat) > 0 {
+		lister.Sort(flat)
+		out.block("", flat)
+	}
+}
+
+func doRecursive(lister *ls.Lister, out *output, root string) {
+	_ = lister.Walk(root, func(dir string, files []ls.File, err error) error {
+		if err != nil {
+			warn(err)
+			return nil
+		}
+
+		out.block(dir, files)
+		return nil
+	})
 }
 
 func doTree(args Args) {
+	if args.json {
+		doLS(args)
+		return
+	}
+
+	lister := ls.NewLister(args.Options)
+	tree := ls.NewTree(args.Options)
 	for _, path := range args.paths {
 		var err error
-		if filepath.IsAbs(path) {
-			err = os.Chdir(path)
+		if lister.IsGlob(path) {
+			err = tree.RenderGlob(bufStdout, path)
 		} else {
-			wd, _ := os.Getwd()
-			err = os.Chdir(filepath.Join(wd, path))
+			err = tree.RenderRoot(bufStdout, path)
 		}
 
 		if err != nil {
-			_, _ = fmt.Fprintln(os.Stderr, err)
-			continue
+			warn(err)
 		}
-
-		files, _ := getFiles(".", args.all)
-
-		clean := filepath.Clean(path)
-		if !args.noColors {
-			clean = theme.ec[category.Dir].Sprint(clean)
-		}
-		_, _ = fmt.Fprintln(bufStdout, clean)
-
-		processTree(files, map[int]bool{0: true}, args)
 	}
 }